@@ -0,0 +1,32 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteProblem(t *testing.T) {
+	p := NewProblem(http.StatusBadRequest, "query parameter `page` must be positive")
+	p.InvalidParams = []InvalidParam{{Name: "page", Reason: "must be positive"}}
+
+	w := httptest.NewRecorder()
+	WriteProblem(w, p)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "query parameter `+"`page`"+` must be positive",
+		"invalid-params": [{"name": "page", "reason": "must be positive"}]
+	}`, w.Body.String())
+}
+
+func TestWriteProblemWithoutStatus(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteProblem(w, Problem{Title: "Something went wrong"})
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,31 @@
+package middlewarex
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/HGV/x/pagination"
+)
+
+// BaseURL makes pagination links use the scheme and host of base instead of
+// those of the request, e.g. for a service behind a proxy.
+func BaseURL(base *url.URL) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx := pagination.WithBaseURL(r.Context(), base)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// ForwardedProto makes pagination links use the scheme of the
+// X-Forwarded-Proto header. Only use it behind a proxy which sets or strips
+// the header.
+func ForwardedProto(next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := pagination.WithForwardedProto(r.Context())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+	return http.HandlerFunc(fn)
+}
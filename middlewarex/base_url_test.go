@@ -0,0 +1,42 @@
+package middlewarex
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/HGV/x/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBaseURL(t *testing.T) {
+	base, _ := url.Parse("https://api.example.com")
+
+	var got string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = pagination.URL(r, url.Values{"page": {"2"}})
+	})
+
+	h := BaseURL(base)(next)
+	r := httptest.NewRequest(http.MethodGet, "http://10.0.0.1/items", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, "https://api.example.com/items?page=2", got)
+}
+
+func TestForwardedProto(t *testing.T) {
+	var got string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = pagination.URL(r, nil)
+	})
+
+	h := ForwardedProto(next)
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/items", nil)
+	r.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, "https://example.com/items", got)
+}
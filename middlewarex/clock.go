@@ -0,0 +1,19 @@
+package middlewarex
+
+import (
+	"net/http"
+
+	"github.com/HGV/x/timex"
+)
+
+// Clock makes c available to handlers via timex.ClockFromContext, e.g. to
+// pin the current time in tests.
+func Clock(c timex.Clock) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx := timex.WithClock(r.Context(), c)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,28 @@
+package middlewarex
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/HGV/x/timex"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	clock := timex.NewFakeClock(now)
+
+	var got time.Time
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = timex.ClockFromContext(r.Context()).Now()
+	})
+
+	h := Clock(clock)(next)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, now, got)
+}
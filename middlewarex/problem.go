@@ -0,0 +1,28 @@
+package middlewarex
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/HGV/x/httpx"
+)
+
+// ProblemErrorHandler is an ErrorHandler writing RFC 9457 problem details.
+// Errors implementing httpx.Problemer, such as invalid pagination parameters,
+// are written as their problem. Any other error becomes a 500 Internal Server
+// Error without details so internals are not leaked.
+func ProblemErrorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	var problemer httpx.Problemer
+	if !errors.As(err, &problemer) {
+		httpx.WriteProblem(w, httpx.NewProblem(http.StatusInternalServerError, ""))
+		return
+	}
+
+	p := problemer.Problem()
+	if p.Instance == "" {
+		p.Instance = r.URL.Path
+	}
+	httpx.WriteProblem(w, p)
+}
+
+var _ ErrorHandler = ProblemErrorHandler
@@ -0,0 +1,75 @@
+package middlewarex
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/HGV/x/pagination/cursorpagination"
+	"github.com/HGV/x/pagination/offsetpagination"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemErrorHandler(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := offsetpagination.Parse[any](r.URL.Query()); err != nil {
+			ProblemErrorHandler(w, r, fmt.Errorf("list items: %w", err))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/items?page=1.5", nil)
+	w := httptest.NewRecorder()
+	next.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "query parameter `+"`page`"+` must be an integer, got \"1.5\"",
+		"instance": "/items",
+		"invalid-params": [{"name": "page", "reason": "must be an integer"}]
+	}`, w.Body.String())
+}
+
+func TestProblemErrorHandlerCursor(t *testing.T) {
+	values := func(id int) []any { return []any{id} }
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := cursorpagination.Parse(r.URL.Query(), []byte("secret"), values, cursorpagination.Key{Column: "id"}); err != nil {
+			ProblemErrorHandler(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/items?after=abc", nil)
+	w := httptest.NewRecorder()
+	next.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "query parameter `+"`after`"+` must be a valid cursor, got \"abc\"",
+		"instance": "/items",
+		"invalid-params": [{"name": "after", "reason": "must be a valid cursor"}]
+	}`, w.Body.String())
+}
+
+func TestProblemErrorHandlerInternalError(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(errors.New("database password is hunter2"))
+	})
+
+	h := Recoverer(ProblemErrorHandler)(next)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.JSONEq(t, `{"title": "Internal Server Error", "status": 500}`, w.Body.String())
+}
@@ -0,0 +1,146 @@
+package cursorpagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/HGV/x/timex"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+var uuidType = reflect.TypeFor[[16]byte]()
+
+// cursorValue is a single sort key value tagged with its type, so it decodes
+// to the same Go type it was encoded from.
+type cursorValue struct {
+	Type  string          `json:"t"`
+	Value json.RawMessage `json:"v,omitempty"`
+}
+
+// encodeCursor encodes values as a signed, opaque and URL-safe string. The
+// signature covers scope, so the cursor is only accepted by decodeCursor with
+// the same scope.
+func encodeCursor(secret []byte, scope string, values []any) (string, error) {
+	tagged := make([]cursorValue, len(values))
+	for i, v := range values {
+		var err error
+		if tagged[i], err = tagValue(v); err != nil {
+			return "", err
+		}
+	}
+
+	payload, err := json.Marshal(tagged)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(append(payload, sign(secret, scope, payload)...)), nil
+}
+
+// decodeCursor verifies and decodes a cursor created by encodeCursor.
+func decodeCursor(secret []byte, scope string, s string) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(data) < sha256.Size {
+		return nil, ErrInvalidCursor
+	}
+	payload, mac := data[:len(data)-sha256.Size], data[len(data)-sha256.Size:]
+	if !hmac.Equal(mac, sign(secret, scope, payload)) {
+		return nil, ErrInvalidCursor
+	}
+
+	var tagged []cursorValue
+	if err := json.Unmarshal(payload, &tagged); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	values := make([]any, len(tagged))
+	for i, tv := range tagged {
+		if values[i], err = untagValue(tv); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return values, nil
+}
+
+func sign(secret []byte, scope string, payload []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(scope))
+	h.Write([]byte{0})
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// tagValue tags v with its type. UUIDs, including named [16]byte types such as
+// uuid.UUID and pgtype.UUID, decode as [16]byte.
+func tagValue(v any) (cursorValue, error) {
+	if u, ok := v.(pgtype.UUID); ok {
+		if !u.Valid {
+			return cursorValue{}, errors.New("cursor value must not be NULL")
+		}
+		v = u.Bytes
+	} else if rv := reflect.ValueOf(v); rv.Kind() == reflect.Array && rv.Type() != uuidType && rv.Type().ConvertibleTo(uuidType) {
+		v = rv.Convert(uuidType).Interface()
+	}
+
+	var tag string
+	switch x := v.(type) {
+	case string:
+		tag = "s"
+	case bool:
+		tag = "b"
+	case int:
+		tag, v = "i", int64(x)
+	case int32:
+		tag, v = "i", int64(x)
+	case int64:
+		tag = "i"
+	case float64:
+		tag = "f"
+	case time.Time:
+		tag = "t"
+	case timex.Date:
+		tag = "d"
+	case [16]byte:
+		tag = "u"
+	default:
+		return cursorValue{}, fmt.Errorf("unsupported cursor value type %T", v)
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return cursorValue{}, err
+	}
+	return cursorValue{Type: tag, Value: raw}, nil
+}
+
+func untagValue(tv cursorValue) (any, error) {
+	switch tv.Type {
+	case "s":
+		return unmarshalValue[string](tv.Value)
+	case "b":
+		return unmarshalValue[bool](tv.Value)
+	case "i":
+		return unmarshalValue[int64](tv.Value)
+	case "f":
+		return unmarshalValue[float64](tv.Value)
+	case "t":
+		return unmarshalValue[time.Time](tv.Value)
+	case "d":
+		return unmarshalValue[timex.Date](tv.Value)
+	case "u":
+		return unmarshalValue[[16]byte](tv.Value)
+	}
+	return nil, fmt.Errorf("unknown cursor value type %q", tv.Type)
+}
+
+func unmarshalValue[T any](data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
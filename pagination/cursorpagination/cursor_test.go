@@ -0,0 +1,92 @@
+package cursorpagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/HGV/x/timex"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSecret = []byte("secret")
+
+const testScope = "name, id"
+
+func TestCursorRoundTrip(t *testing.T) {
+	values := []any{
+		"abc",
+		true,
+		int64(42),
+		1.5,
+		time.Date(2025, 7, 1, 12, 30, 0, 0, time.UTC),
+		timex.Date{Year: 2025, Month: time.July, Day: 1},
+		[16]byte{1, 2, 3},
+	}
+
+	cursor, err := encodeCursor(testSecret, testScope, values)
+	assert.NoError(t, err)
+
+	decoded, err := decodeCursor(testSecret, testScope, cursor)
+	assert.NoError(t, err)
+	assert.Equal(t, values, decoded)
+}
+
+func TestCursorIntegers(t *testing.T) {
+	cursor, err := encodeCursor(testSecret, testScope, []any{1, int32(2)})
+	assert.NoError(t, err)
+
+	decoded, err := decodeCursor(testSecret, testScope, cursor)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{int64(1), int64(2)}, decoded)
+}
+
+func TestCursorUUIDs(t *testing.T) {
+	type UUID [16]byte
+	want := [16]byte{1, 2, 3}
+
+	for _, v := range []any{UUID(want), pgtype.UUID{Bytes: want, Valid: true}} {
+		cursor, err := encodeCursor(testSecret, testScope, []any{v})
+		assert.NoError(t, err)
+
+		decoded, err := decodeCursor(testSecret, testScope, cursor)
+		assert.NoError(t, err)
+		assert.Equal(t, []any{want}, decoded)
+	}
+
+	_, err := encodeCursor(testSecret, testScope, []any{pgtype.UUID{}})
+	assert.Error(t, err)
+}
+
+func TestCursorUnsupportedType(t *testing.T) {
+	_, err := encodeCursor(testSecret, testScope, []any{nil})
+	assert.Error(t, err)
+}
+
+func TestCursorInvalid(t *testing.T) {
+	cursor, err := encodeCursor(testSecret, testScope, []any{"abc"})
+	assert.NoError(t, err)
+
+	tampered := []byte(cursor)
+	tampered[2] ^= 1
+
+	tests := []struct {
+		secret []byte
+		scope  string
+		cursor string
+	}{
+		{testSecret, testScope, ""},
+		{testSecret, testScope, "!!!"},
+		{testSecret, testScope, "YWJj"},
+		{testSecret, testScope, string(tampered)},
+		{[]byte("other"), testScope, cursor},
+		{testSecret, "name DESC, id DESC", cursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cursor, func(t *testing.T) {
+			_, err := decodeCursor(tt.secret, tt.scope, tt.cursor)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
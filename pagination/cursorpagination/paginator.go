@@ -0,0 +1,353 @@
+package cursorpagination
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/HGV/x/pagination"
+)
+
+const (
+	defaultPageSize int = 50
+	maxPageSize     int = 100
+)
+
+type (
+	// Paged yields the SQL fragments selecting a page, e.g.
+	//
+	//	where, args := p.Where(1)
+	//	query := "SELECT … FROM t WHERE tenant = $1"
+	//	if where != "" {
+	//		query += " AND " + where
+	//	}
+	//	query += " ORDER BY " + p.OrderBy() + " LIMIT " + strconv.Itoa(p.Limit())
+	Paged interface {
+		// Where returns the condition selecting the rows after (or before)
+		// the cursor, with placeholders numbered from argOffset+1, and its
+		// arguments. It returns an empty string if there is no cursor.
+		Where(argOffset int) (string, []any)
+		OrderBy() string
+		Limit() int
+	}
+	// Key is a column or expression the rows are sorted by. The keys of a
+	// Paginator must uniquely identify a row and must not be NULL. Column is
+	// inserted into the SQL verbatim and must never come from user input.
+	Key struct {
+		Column string
+		Desc   bool
+	}
+	Paginator[T any] struct {
+		secret   []byte
+		scope    string
+		keys     []Key
+		values   func(T) []any
+		pageSize int
+		cursor   []any
+		backward bool
+	}
+	Result[T any] struct {
+		Items      []T               `json:"items"`
+		NextCursor string            `json:"next_cursor,omitempty"`
+		PrevCursor string            `json:"prev_cursor,omitempty"`
+		Links      *pagination.Links `json:"links,omitempty"`
+	}
+)
+
+var _ Paged = new(Paginator[any])
+
+var ErrEmptySecret = errors.New("cursor secret must not be empty")
+
+// New returns a Paginator for the first page of the default size. Cursors
+// are signed with secret and keys, so they are rejected by a Paginator with
+// other keys, and values returns the values of keys for an item, in the same
+// order.
+func New[T any](secret []byte, values func(T) []any, keys ...Key) (Paginator[T], error) {
+	if len(secret) == 0 {
+		return Paginator[T]{}, ErrEmptySecret
+	}
+	return Paginator[T]{
+		secret:   secret,
+		scope:    keyScope(keys),
+		keys:     keys,
+		values:   values,
+		pageSize: defaultPageSize,
+	}, nil
+}
+
+func keyScope(keys []Key) string {
+	terms := make([]string, len(keys))
+	for i, k := range keys {
+		terms[i] = k.Column
+		if k.Desc {
+			terms[i] += " DESC"
+		}
+	}
+	return strings.Join(terms, ", ")
+}
+
+// First returns a Paginator for the first n items, or the first n items after
+// the cursor if combined with After.
+func (p Paginator[T]) First(n int) Paginator[T] {
+	p.pageSize = clampPageSize(n)
+	p.backward = false
+	return p
+}
+
+// Last returns a Paginator for the last n items, or the last n items before
+// the cursor if combined with Before.
+func (p Paginator[T]) Last(n int) Paginator[T] {
+	p.pageSize = clampPageSize(n)
+	p.backward = true
+	return p
+}
+
+// After returns a Paginator for the items following the cursor.
+func (p Paginator[T]) After(cursor string) (Paginator[T], error) {
+	return p.withCursor(cursor, false)
+}
+
+// Before returns a Paginator for the items preceding the cursor.
+func (p Paginator[T]) Before(cursor string) (Paginator[T], error) {
+	return p.withCursor(cursor, true)
+}
+
+func (p Paginator[T]) withCursor(cursor string, backward bool) (Paginator[T], error) {
+	values, err := decodeCursor(p.secret, p.scope, cursor)
+	if err != nil {
+		return p, err
+	}
+	if len(values) != len(p.keys) {
+		return p, ErrInvalidCursor
+	}
+	p.cursor, p.backward = values, backward
+	return p, nil
+}
+
+func clampPageSize(n int) int {
+	if n <= 0 {
+		return defaultPageSize
+	}
+	return min(n, maxPageSize)
+}
+
+func (p Paginator[T]) PageSize() int {
+	return p.pageSize
+}
+
+func (p Paginator[T]) Where(argOffset int) (string, []any) {
+	if p.cursor == nil {
+		return "", nil
+	}
+
+	placeholder := func(i int) string {
+		return "$" + strconv.Itoa(argOffset+i+1)
+	}
+
+	if p.sameDirection() {
+		columns := make([]string, len(p.keys))
+		placeholders := make([]string, len(p.keys))
+		for i, k := range p.keys {
+			columns[i], placeholders[i] = k.Column, placeholder(i)
+		}
+		return fmt.Sprintf("(%s) %s (%s)",
+			strings.Join(columns, ", "), p.operator(p.keys[0]), strings.Join(placeholders, ", "),
+		), slices.Clone(p.cursor)
+	}
+
+	// Mixed directions cannot be expressed as a row comparison, so expand
+	// (a, b) > (x, y) into a > x OR (a = x AND b < y).
+	terms := make([]string, len(p.keys))
+	for i, k := range p.keys {
+		var conds []string
+		for j := range i {
+			conds = append(conds, p.keys[j].Column+" = "+placeholder(j))
+		}
+		conds = append(conds, k.Column+" "+p.operator(k)+" "+placeholder(i))
+		terms[i] = "(" + strings.Join(conds, " AND ") + ")"
+	}
+	return "(" + strings.Join(terms, " OR ") + ")", slices.Clone(p.cursor)
+}
+
+func (p Paginator[T]) sameDirection() bool {
+	for _, k := range p.keys {
+		if k.Desc != p.keys[0].Desc {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Paginator[T]) operator(k Key) string {
+	if k.Desc != p.backward {
+		return "<"
+	}
+	return ">"
+}
+
+// OrderBy returns the sort order of the query. When paging backwards it is
+// reversed, and Paginate restores the original order.
+func (p Paginator[T]) OrderBy() string {
+	terms := make([]string, len(p.keys))
+	for i, k := range p.keys {
+		dir := "ASC"
+		if k.Desc != p.backward {
+			dir = "DESC"
+		}
+		terms[i] = k.Column + " " + dir
+	}
+	return strings.Join(terms, ", ")
+}
+
+func (p Paginator[T]) Limit() int {
+	return p.pageSize + 1
+}
+
+// Paginate turns the rows fetched with Limit into a page with cursors to the
+// adjacent pages.
+func (p Paginator[T]) Paginate(items []T) (Result[T], error) {
+	hasMore := len(items) > p.pageSize
+	if hasMore {
+		items = items[:p.pageSize]
+	}
+	items = slices.Clone(items)
+	if p.backward {
+		slices.Reverse(items)
+	}
+
+	result := Result[T]{Items: items}
+	if len(items) == 0 {
+		return result, nil
+	}
+
+	hasNext, hasPrev := hasMore, p.cursor != nil
+	if p.backward {
+		hasNext, hasPrev = hasPrev, hasNext
+	}
+
+	var err error
+	if hasNext {
+		if result.NextCursor, err = encodeCursor(p.secret, p.scope, p.values(items[len(items)-1])); err != nil {
+			return Result[T]{}, err
+		}
+	}
+	if hasPrev {
+		if result.PrevCursor, err = encodeCursor(p.secret, p.scope, p.values(items[0])); err != nil {
+			return Result[T]{}, err
+		}
+	}
+	return result, nil
+}
+
+// Links returns the URLs of the pages adjacent to result, which p returned
+// for r, using the query parameters read by Parse.
+func (p Paginator[T]) Links(r *http.Request, result Result[T]) pagination.Links {
+	pageSize := []string{strconv.Itoa(p.pageSize)}
+	forward := func(cursor string) string {
+		set := url.Values{"first": pageSize}
+		if cursor != "" {
+			set.Set("after", cursor)
+		}
+		return pagination.URL(r, set, "after", "before", "last")
+	}
+	backward := func(cursor string) string {
+		set := url.Values{"last": pageSize}
+		if cursor != "" {
+			set.Set("before", cursor)
+		}
+		return pagination.URL(r, set, "after", "before", "first")
+	}
+
+	links := pagination.Links{
+		First: forward(""),
+		Last:  backward(""),
+	}
+	if result.HasNextPage() {
+		links.Next = forward(result.NextCursor)
+	}
+	if result.HasPrevPage() {
+		links.Prev = backward(result.PrevCursor)
+	}
+	return links
+}
+
+func (r Result[T]) HasNextPage() bool {
+	return r.NextCursor != ""
+}
+
+func (r Result[T]) HasPrevPage() bool {
+	return r.PrevCursor != ""
+}
+
+// Parse returns a Paginator configured by the query parameters first, after,
+// last and before. A next page is requested with after and a previous page
+// with before, each optionally combined with a page size in first or last.
+// Invalid query parameters are reported as a *pagination.ParamError.
+func Parse[T any](q url.Values, secret []byte, values func(T) []any, keys ...Key) (*Paginator[T], error) {
+	p, err := New(secret, values, keys...)
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := parsePageSize(q, "first")
+	if err != nil {
+		return nil, err
+	}
+	last, err := parsePageSize(q, "last")
+	if err != nil {
+		return nil, err
+	}
+	after, before := q.Get("after"), q.Get("before")
+
+	switch {
+	case first != 0 && last != 0:
+		return nil, conflictError(q, "last", "first")
+	case after != "" && before != "":
+		return nil, conflictError(q, "before", "after")
+	case last != 0 || before != "":
+		p = p.Last(cmp.Or(last, first))
+	default:
+		p = p.First(first)
+	}
+
+	if after != "" {
+		if p, err = p.After(after); err != nil {
+			return nil, &pagination.ParamError{Param: "after", Value: after, Reason: pagination.ReasonInvalidCursor}
+		}
+	}
+	if before != "" {
+		if p, err = p.Before(before); err != nil {
+			return nil, &pagination.ParamError{Param: "before", Value: before, Reason: pagination.ReasonInvalidCursor}
+		}
+	}
+	return &p, nil
+}
+
+func parsePageSize(q url.Values, param string) (int, error) {
+	s := q.Get(param)
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, &pagination.ParamError{Param: param, Value: s, Reason: pagination.ReasonNotInteger}
+	}
+	if n <= 0 {
+		return 0, &pagination.ParamError{Param: param, Value: s, Reason: pagination.ReasonNotPositive}
+	}
+	return n, nil
+}
+
+func conflictError(q url.Values, param, conflict string) error {
+	return &pagination.ParamError{
+		Param:    param,
+		Value:    q.Get(param),
+		Reason:   pagination.ReasonConflict,
+		Conflict: conflict,
+	}
+}
@@ -0,0 +1,282 @@
+package cursorpagination
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/HGV/x/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	Name string
+	ID   int
+}
+
+func itemValues(i item) []any {
+	return []any{i.Name, i.ID}
+}
+
+func mustNew(keys ...Key) Paginator[item] {
+	p, err := New(testSecret, itemValues, keys...)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(nil, itemValues, Key{Column: "id"})
+	assert.ErrorIs(t, err, ErrEmptySecret)
+	_, err = Parse(url.Values{}, []byte{}, itemValues, Key{Column: "id"})
+	assert.ErrorIs(t, err, ErrEmptySecret)
+}
+
+func TestCursorBoundToKeys(t *testing.T) {
+	cursor := mustEncode(item{Name: "b", ID: 2})
+
+	_, err := mustNew(Key{Column: "name"}, Key{Column: "id"}).After(cursor)
+	assert.NoError(t, err)
+	_, err = mustNew(Key{Column: "title"}, Key{Column: "id"}).After(cursor)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = mustNew(Key{Column: "name", Desc: true}, Key{Column: "id", Desc: true}).Before(cursor)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestFirstAndLast(t *testing.T) {
+	p := mustNew(Key{Column: "name"}, Key{Column: "id"})
+	assert.Equal(t, defaultPageSize, p.PageSize())
+	assert.Equal(t, 10, p.First(10).PageSize())
+	assert.Equal(t, maxPageSize, p.Last(300).PageSize())
+	assert.Equal(t, defaultPageSize, p.First(-1).PageSize())
+	assert.Equal(t, 11, p.First(10).Limit())
+}
+
+func TestWhereAndOrderBy(t *testing.T) {
+	asc := mustNew(Key{Column: "name"}, Key{Column: "id"})
+	desc := mustNew(Key{Column: "name", Desc: true}, Key{Column: "id", Desc: true})
+	mixed := mustNew(Key{Column: "name", Desc: true}, Key{Column: "id"})
+
+	after := func(p Paginator[item]) Paginator[item] {
+		cursor, _ := encodeCursor(testSecret, p.scope, []any{"b", 2})
+		p, _ = p.After(cursor)
+		return p
+	}
+	before := func(p Paginator[item]) Paginator[item] {
+		cursor, _ := encodeCursor(testSecret, p.scope, []any{"b", 2})
+		p, _ = p.Before(cursor)
+		return p
+	}
+
+	tests := []struct {
+		p               Paginator[item]
+		expectedWhere   string
+		expectedOrderBy string
+	}{
+		{
+			p:               asc,
+			expectedWhere:   "",
+			expectedOrderBy: "name ASC, id ASC",
+		},
+		{
+			p:               after(asc),
+			expectedWhere:   "(name, id) > ($2, $3)",
+			expectedOrderBy: "name ASC, id ASC",
+		},
+		{
+			p:               before(asc),
+			expectedWhere:   "(name, id) < ($2, $3)",
+			expectedOrderBy: "name DESC, id DESC",
+		},
+		{
+			p:               after(desc),
+			expectedWhere:   "(name, id) < ($2, $3)",
+			expectedOrderBy: "name DESC, id DESC",
+		},
+		{
+			p:               after(mixed),
+			expectedWhere:   "((name < $2) OR (name = $2 AND id > $3))",
+			expectedOrderBy: "name DESC, id ASC",
+		},
+		{
+			p:               before(mixed),
+			expectedWhere:   "((name > $2) OR (name = $2 AND id < $3))",
+			expectedOrderBy: "name ASC, id DESC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expectedWhere, func(t *testing.T) {
+			where, args := tt.p.Where(1)
+			assert.Equal(t, tt.expectedWhere, where)
+			if where != "" {
+				assert.Equal(t, []any{"b", int64(2)}, args)
+			}
+			assert.Equal(t, tt.expectedOrderBy, tt.p.OrderBy())
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	items := make([]item, 10)
+	for i := range items {
+		items[i] = item{Name: "item", ID: i}
+	}
+	p := mustNew(Key{Column: "name"}, Key{Column: "id"}).First(3)
+
+	t.Run("first page", func(t *testing.T) {
+		result, err := p.Paginate(items[:4])
+		assert.NoError(t, err)
+		assert.Equal(t, items[:3], result.Items)
+		assert.True(t, result.HasNextPage())
+		assert.False(t, result.HasPrevPage())
+
+		next, err := p.After(result.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, []any{"item", int64(2)}, next.cursor)
+	})
+
+	t.Run("middle page", func(t *testing.T) {
+		next, _ := p.After(mustEncode(items[2]))
+		result, err := next.Paginate(items[3:7])
+		assert.NoError(t, err)
+		assert.Equal(t, items[3:6], result.Items)
+		assert.True(t, result.HasNextPage())
+		assert.True(t, result.HasPrevPage())
+
+		prev, _ := next.Before(result.PrevCursor)
+		assert.Equal(t, []any{"item", int64(3)}, prev.cursor)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		next, _ := p.After(mustEncode(items[7]))
+		result, err := next.Paginate(items[8:])
+		assert.NoError(t, err)
+		assert.Equal(t, items[8:], result.Items)
+		assert.False(t, result.HasNextPage())
+		assert.True(t, result.HasPrevPage())
+	})
+
+	t.Run("backward", func(t *testing.T) {
+		// Rows arrive in reversed order when paging backwards.
+		prev, _ := p.Before(mustEncode(items[6]))
+		result, err := prev.Paginate([]item{items[5], items[4], items[3], items[2]})
+		assert.NoError(t, err)
+		assert.Equal(t, items[3:6], result.Items)
+		assert.True(t, result.HasNextPage())
+		assert.True(t, result.HasPrevPage())
+	})
+
+	t.Run("backward to first page", func(t *testing.T) {
+		prev, _ := p.Before(mustEncode(items[3]))
+		result, err := prev.Paginate([]item{items[2], items[1], items[0]})
+		assert.NoError(t, err)
+		assert.Equal(t, items[:3], result.Items)
+		assert.True(t, result.HasNextPage())
+		assert.False(t, result.HasPrevPage())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		result, err := p.Paginate(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, result.Items)
+		assert.False(t, result.HasNextPage())
+		assert.False(t, result.HasPrevPage())
+	})
+}
+
+func mustEncode(i item) string {
+	return mustEncodeValues(itemValues(i)...)
+}
+
+func TestParse(t *testing.T) {
+	cursor := mustEncode(item{Name: "b", ID: 2})
+
+	r := httptest.NewRequest(http.MethodGet, "/items?first=20&after="+cursor, nil)
+	p, err := Parse(r.URL.Query(), testSecret, itemValues, Key{Column: "name"}, Key{Column: "id"})
+	assert.NoError(t, err)
+	assert.Equal(t, 20, p.pageSize)
+	assert.False(t, p.backward)
+	assert.Equal(t, []any{"b", int64(2)}, p.cursor)
+
+	r = httptest.NewRequest(http.MethodGet, "/items?before="+cursor, nil)
+	p, err = Parse(r.URL.Query(), testSecret, itemValues, Key{Column: "name"}, Key{Column: "id"})
+	assert.NoError(t, err)
+	assert.Equal(t, defaultPageSize, p.pageSize)
+	assert.True(t, p.backward)
+}
+
+func TestParseInvalid(t *testing.T) {
+	cursor := mustEncode(item{Name: "b", ID: 2})
+	short := mustEncodeValues("b")
+
+	tests := []struct {
+		query    url.Values
+		expected pagination.ParamError
+	}{
+		{
+			query:    url.Values{"first": {"abc"}},
+			expected: pagination.ParamError{Param: "first", Value: "abc", Reason: pagination.ReasonNotInteger},
+		},
+		{
+			query:    url.Values{"first": {"0"}},
+			expected: pagination.ParamError{Param: "first", Value: "0", Reason: pagination.ReasonNotPositive},
+		},
+		{
+			query:    url.Values{"first": {"10"}, "last": {"20"}},
+			expected: pagination.ParamError{Param: "last", Value: "20", Reason: pagination.ReasonConflict, Conflict: "first"},
+		},
+		{
+			query:    url.Values{"after": {cursor}, "before": {"x"}},
+			expected: pagination.ParamError{Param: "before", Value: "x", Reason: pagination.ReasonConflict, Conflict: "after"},
+		},
+		{
+			query:    url.Values{"after": {"invalid"}},
+			expected: pagination.ParamError{Param: "after", Value: "invalid", Reason: pagination.ReasonInvalidCursor},
+		},
+		{
+			query:    url.Values{"before": {short}},
+			expected: pagination.ParamError{Param: "before", Value: short, Reason: pagination.ReasonInvalidCursor},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query.Encode(), func(t *testing.T) {
+			_, err := Parse(tt.query, testSecret, itemValues, Key{Column: "name"}, Key{Column: "id"})
+
+			var paramErr *pagination.ParamError
+			if assert.True(t, errors.As(err, &paramErr)) {
+				assert.Equal(t, tt.expected, *paramErr)
+			}
+		})
+	}
+}
+
+func mustEncodeValues(values ...any) string {
+	cursor, err := encodeCursor(testSecret, testScope, values)
+	if err != nil {
+		panic(err)
+	}
+	return cursor
+}
+
+func TestLinks(t *testing.T) {
+	cursor := mustEncode(item{Name: "b", ID: 2})
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/items?q=foo&first=3&after="+cursor, nil)
+	p, _ := Parse(r.URL.Query(), testSecret, itemValues, Key{Column: "name"}, Key{Column: "id"})
+
+	items := []item{{"c", 3}, {"d", 4}, {"e", 5}, {"f", 6}}
+	result, _ := p.Paginate(items)
+
+	next := url.Values{"q": {"foo"}, "first": {"3"}, "after": {result.NextCursor}}
+	prev := url.Values{"q": {"foo"}, "last": {"3"}, "before": {result.PrevCursor}}
+	assert.Equal(t, pagination.Links{
+		First: "http://example.com/items?first=3&q=foo",
+		Prev:  "http://example.com/items?" + prev.Encode(),
+		Next:  "http://example.com/items?" + next.Encode(),
+		Last:  "http://example.com/items?last=3&q=foo",
+	}, p.Links(r, result))
+}
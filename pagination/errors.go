@@ -0,0 +1,57 @@
+package pagination
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/HGV/x/httpx"
+)
+
+// Reasons a query parameter is rejected.
+const (
+	ReasonNotInteger  = "must be an integer"
+	ReasonNotPositive = "must be positive"
+	ReasonNegative    = "must be non-negative"
+	// ReasonTooLarge is followed by the Limit of the ParamError.
+	ReasonTooLarge = "must not exceed"
+	// ReasonConflict is followed by the Conflict of the ParamError.
+	ReasonConflict      = "must not be combined with"
+	ReasonInvalidCursor = "must be a valid cursor"
+)
+
+// ParamError is returned by the Parse functions of the pagination packages
+// for an invalid query parameter.
+type ParamError struct {
+	Param  string
+	Value  string
+	Reason string
+	// Limit is the largest allowed value if Reason is ReasonTooLarge.
+	Limit int
+	// Conflict is the query parameter Param must not be combined with if
+	// Reason is ReasonConflict.
+	Conflict string
+}
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("query parameter `%s` %s, got %q", e.Param, e.reason(), e.Value)
+}
+
+func (e *ParamError) reason() string {
+	switch e.Reason {
+	case ReasonTooLarge:
+		return e.Reason + " " + strconv.Itoa(e.Limit)
+	case ReasonConflict:
+		return e.Reason + " `" + e.Conflict + "`"
+	}
+	return e.Reason
+}
+
+// Problem returns e as a 400 Bad Request problem.
+func (e *ParamError) Problem() httpx.Problem {
+	p := httpx.NewProblem(http.StatusBadRequest, e.Error())
+	p.InvalidParams = []httpx.InvalidParam{{Name: e.Param, Reason: e.reason()}}
+	return p
+}
+
+var _ httpx.Problemer = &ParamError{}
@@ -0,0 +1,45 @@
+package pagination
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/HGV/x/httpx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamError(t *testing.T) {
+	tests := []struct {
+		err            ParamError
+		expectedError  string
+		expectedReason string
+	}{
+		{
+			err:            ParamError{Param: "page", Value: "abc", Reason: ReasonNotInteger},
+			expectedError:  "query parameter `page` must be an integer, got \"abc\"",
+			expectedReason: "must be an integer",
+		},
+		{
+			err:            ParamError{Param: "page_size", Value: "200", Reason: ReasonTooLarge, Limit: 100},
+			expectedError:  "query parameter `page_size` must not exceed 100, got \"200\"",
+			expectedReason: "must not exceed 100",
+		},
+		{
+			err:            ParamError{Param: "last", Value: "10", Reason: ReasonConflict, Conflict: "first"},
+			expectedError:  "query parameter `last` must not be combined with `first`, got \"10\"",
+			expectedReason: "must not be combined with `first`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expectedError, func(t *testing.T) {
+			assert.Equal(t, tt.expectedError, tt.err.Error())
+			assert.Equal(t, httpx.Problem{
+				Title:         "Bad Request",
+				Status:        http.StatusBadRequest,
+				Detail:        tt.expectedError,
+				InvalidParams: []httpx.InvalidParam{{Name: tt.err.Param, Reason: tt.expectedReason}},
+			}, tt.err.Problem())
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package pagination contains helpers shared by the offset and cursor
+// pagination packages.
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Links are absolute URLs of the pages adjacent to the current one. Missing
+// pages are empty.
+type Links struct {
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
+
+// Header returns l as the value of an RFC 8288 Link header, e.g.
+// <https://example.com/items?page=2>; rel="next".
+func (l Links) Header() string {
+	var links []string
+	for _, link := range []struct{ url, rel string }{
+		{l.First, "first"},
+		{l.Prev, "prev"},
+		{l.Next, "next"},
+		{l.Last, "last"},
+	} {
+		if link.url != "" {
+			links = append(links, "<"+link.url+`>; rel="`+link.rel+`"`)
+		}
+	}
+	return strings.Join(links, ", ")
+}
+
+// WriteLinkHeader adds l as a Link header to w unless l is empty.
+func WriteLinkHeader(w http.ResponseWriter, l Links) {
+	if h := l.Header(); h != "" {
+		w.Header().Add("Link", h)
+	}
+}
+
+type (
+	baseURLContextKey        struct{}
+	forwardedProtoContextKey struct{}
+)
+
+// WithBaseURL returns a copy of ctx making URL use the scheme and host of
+// base, e.g. the public URL of a service behind a proxy, instead of those of
+// the request.
+func WithBaseURL(ctx context.Context, base *url.URL) context.Context {
+	return context.WithValue(ctx, baseURLContextKey{}, base)
+}
+
+// WithForwardedProto returns a copy of ctx making URL take the scheme from
+// the X-Forwarded-Proto header. Only use it for requests that come through a
+// proxy which sets or strips the header.
+func WithForwardedProto(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forwardedProtoContextKey{}, true)
+}
+
+// URL returns the absolute URL of r with the query parameters in set replaced
+// and those in del removed. All other query parameters are kept. The scheme
+// and host are those of the base URL set with WithBaseURL, or else those of
+// r. If r has no valid host, URL returns a URL relative to the host.
+func URL(r *http.Request, set url.Values, del ...string) string {
+	q := r.URL.Query()
+	for _, param := range del {
+		q.Del(param)
+	}
+	for param, values := range set {
+		q[param] = values
+	}
+
+	u := url.URL{
+		Path:     r.URL.Path,
+		RawPath:  r.URL.RawPath,
+		RawQuery: q.Encode(),
+	}
+	if base, ok := r.Context().Value(baseURLContextKey{}).(*url.URL); ok {
+		u.Scheme, u.Host = base.Scheme, base.Host
+	} else if validHost(r.Host) {
+		u.Scheme, u.Host = scheme(r), r.Host
+	}
+	return u.String()
+}
+
+func scheme(r *http.Request) string {
+	if trusted, _ := r.Context().Value(forwardedProtoContextKey{}).(bool); trusted {
+		// A proxy chain may append its own value after the client's.
+		proto, _, _ := strings.Cut(r.Header.Get("X-Forwarded-Proto"), ",")
+		switch proto = strings.ToLower(strings.TrimSpace(proto)); proto {
+		case "http", "https":
+			return proto
+		}
+	}
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// validHost reports whether host is a host name or IP address with an
+// optional port, as opposed to arbitrary text from a Host header.
+func validHost(host string) bool {
+	if host == "" {
+		return false
+	}
+	for _, c := range host {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.ContainsRune("-._:[]", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURL(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/items?q=foo&page=2&sort=name", nil)
+
+	tests := []struct {
+		set      url.Values
+		del      []string
+		expected string
+	}{
+		{
+			set:      url.Values{"page": {"3"}},
+			expected: "http://example.com/items?page=3&q=foo&sort=name",
+		},
+		{
+			del:      []string{"page", "sort"},
+			expected: "http://example.com/items?q=foo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, URL(r, tt.set, tt.del...))
+		})
+	}
+}
+
+func TestURLScheme(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "https://example.com/items", nil)
+	assert.Equal(t, "https://example.com/items", URL(r, nil))
+
+	tests := []struct {
+		proto    string
+		trusted  bool
+		expected string
+	}{
+		{proto: "https", expected: "http://example.com/items"},
+		{proto: "https", trusted: true, expected: "https://example.com/items"},
+		{proto: "HTTPS, http", trusted: true, expected: "https://example.com/items"},
+		{proto: "javascript", trusted: true, expected: "http://example.com/items"},
+		{proto: "", trusted: true, expected: "http://example.com/items"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.proto, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "http://example.com/items", nil)
+			r.Header.Set("X-Forwarded-Proto", tt.proto)
+			if tt.trusted {
+				r = r.WithContext(WithForwardedProto(r.Context()))
+			}
+			assert.Equal(t, tt.expected, URL(r, nil))
+		})
+	}
+}
+
+func TestURLBaseURL(t *testing.T) {
+	base, _ := url.Parse("https://api.example.com")
+	r := httptest.NewRequest(http.MethodGet, "http://10.0.0.1:8080/items?page=2", nil)
+	r = r.WithContext(WithBaseURL(r.Context(), base))
+	assert.Equal(t, "https://api.example.com/items?page=3", URL(r, url.Values{"page": {"3"}}))
+}
+
+func TestURLInvalidHost(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "http://example.com/items?page=2", nil)
+	r.Host = "evil.com/@example.com"
+	assert.Equal(t, "/items?page=3", URL(r, url.Values{"page": {"3"}}))
+
+	r.Host = ""
+	assert.Equal(t, "/items?page=3", URL(r, url.Values{"page": {"3"}}))
+}
+
+func TestWriteLinkHeader(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteLinkHeader(w, Links{
+		First: "http://example.com/items?page=1",
+		Next:  "http://example.com/items?page=3",
+	})
+	assert.Equal(t, `<http://example.com/items?page=1>; rel="first", <http://example.com/items?page=3>; rel="next"`, w.Header().Get("Link"))
+
+	w = httptest.NewRecorder()
+	WriteLinkHeader(w, Links{})
+	assert.Empty(t, w.Header().Values("Link"))
+}
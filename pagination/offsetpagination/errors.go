@@ -0,0 +1,14 @@
+package offsetpagination
+
+import "github.com/HGV/x/pagination"
+
+// Reasons a query parameter is rejected by Parse.
+const (
+	ReasonNotInteger  = pagination.ReasonNotInteger
+	ReasonNotPositive = pagination.ReasonNotPositive
+	ReasonNegative    = pagination.ReasonNegative
+	ReasonTooLarge    = pagination.ReasonTooLarge
+)
+
+// ParamError is returned by Parse for an invalid query parameter.
+type ParamError = pagination.ParamError
@@ -0,0 +1,65 @@
+package offsetpagination
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/HGV/x/httpx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseParamError(t *testing.T) {
+	tests := []struct {
+		query    string
+		opts     []Option
+		expected ParamError
+	}{
+		{
+			query:    "page=1.5",
+			expected: ParamError{Param: "page", Value: "1.5", Reason: ReasonNotInteger},
+		},
+		{
+			query:    "page=0",
+			expected: ParamError{Param: "page", Value: "0", Reason: ReasonNotPositive},
+		},
+		{
+			query:    "page_size=-5",
+			expected: ParamError{Param: "page_size", Value: "-5", Reason: ReasonNotPositive},
+		},
+		{
+			query:    "offset=-1",
+			opts:     []Option{WithOffsetParams("offset", "limit")},
+			expected: ParamError{Param: "offset", Value: "-1", Reason: ReasonNegative},
+		},
+		{
+			query:    "page_size=101",
+			opts:     []Option{WithRejectOversized()},
+			expected: ParamError{Param: "page_size", Value: "101", Reason: ReasonTooLarge, Limit: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			_, err := Parse[any](q, tt.opts...)
+
+			var paramErr *ParamError
+			if assert.True(t, errors.As(err, &paramErr)) {
+				assert.Equal(t, tt.expected, *paramErr)
+			}
+		})
+	}
+}
+
+func TestParamErrorProblem(t *testing.T) {
+	err := &ParamError{Param: "page", Value: "abc", Reason: ReasonNotInteger}
+	assert.Equal(t, "query parameter `page` must be an integer, got \"abc\"", err.Error())
+	assert.Equal(t, httpx.Problem{
+		Title:         "Bad Request",
+		Status:        http.StatusBadRequest,
+		Detail:        err.Error(),
+		InvalidParams: []httpx.InvalidParam{{Name: "page", Reason: ReasonNotInteger}},
+	}, err.Problem())
+}
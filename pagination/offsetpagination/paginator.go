@@ -1,9 +1,12 @@
 package offsetpagination
 
 import (
-	"errors"
+	"cmp"
+	"net/http"
 	"net/url"
 	"strconv"
+
+	"github.com/HGV/x/pagination"
 )
 
 const (
@@ -16,45 +19,149 @@ type (
 		Offset() int
 		Limit() int
 	}
+	// Paginator selects a page either by page number or, if created with
+	// NewFromOffset or parsed with WithOffsetParams, by row offset.
 	Paginator[T any] struct {
+		cfg      config
 		page     int
 		pageSize int
+		offset   int
 	}
 	Result[T any] struct {
-		Items    []T `json:"items"`
-		NextPage int `json:"next_page,omitempty"`
+		Items      []T `json:"items"`
+		NextPage   int `json:"next_page,omitempty"`
+		NextOffset int `json:"next_offset,omitempty"`
+		// Totals is only set by PaginateWithTotal, and by PaginateCounted
+		// if there are items.
+		*Totals
+		Links *pagination.Links `json:"links,omitempty"`
+	}
+	Totals struct {
+		TotalItems int  `json:"total_items"`
+		TotalPages int  `json:"total_pages"`
+		Page       int  `json:"page"`
+		PrevPage   int  `json:"prev_page,omitempty"`
+		HasMore    bool `json:"has_more"`
 	}
 )
 
+// TotalCountColumn selects the number of rows matching a query regardless of
+// LIMIT and OFFSET, to be scanned alongside each row and passed to
+// PaginateCounted, e.g.
+//
+//	SELECT id, name, COUNT(*) OVER() AS total_count FROM t ORDER BY id LIMIT $1 OFFSET $2
+const TotalCountColumn = "COUNT(*) OVER() AS total_count"
+
 var _ Paged = new(Paginator[any])
 
-func New[T any](page, pageSize int) Paginator[T] {
-	p := Paginator[T]{
-		page:     page,
-		pageSize: pageSize,
+type Option func(*config)
+
+type config struct {
+	defaultPageSize int
+	maxPageSize     int
+	pageParam       string
+	pageSizeParam   string
+	offsetParam     string
+	rejectOversized bool
+}
+
+func defaultConfig() config {
+	return config{
+		defaultPageSize: defaultPageSize,
+		maxPageSize:     maxPageSize,
+		pageParam:       "page",
+		pageSizeParam:   "page_size",
+	}
+}
+
+func newConfig(opts []Option) config {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cfg.defaultPageSize = min(cfg.defaultPageSize, cfg.maxPageSize)
+	return cfg
+}
+
+// WithDefaultPageSize sets the page size used if none is given.
+func WithDefaultPageSize(n int) Option {
+	return func(cfg *config) {
+		if n > 0 {
+			cfg.defaultPageSize = n
+		}
+	}
+}
+
+// WithMaxPageSize sets the largest allowed page size.
+func WithMaxPageSize(n int) Option {
+	return func(cfg *config) {
+		if n > 0 {
+			cfg.maxPageSize = n
+		}
 	}
+}
 
-	if p.page <= 0 {
-		p.page = 1
+// WithPageParams sets the names of the query parameters Parse reads the page
+// number and page size from, by default page and page_size.
+func WithPageParams(page, pageSize string) Option {
+	return func(cfg *config) {
+		cfg.pageParam, cfg.pageSizeParam, cfg.offsetParam = page, pageSize, ""
 	}
-	if p.pageSize <= 0 {
-		p.pageSize = defaultPageSize
+}
+
+// WithOffsetParams makes Parse read a row offset and a page size instead of a
+// page number, e.g. WithOffsetParams("offset", "limit").
+func WithOffsetParams(offset, limit string) Option {
+	return func(cfg *config) {
+		cfg.offsetParam, cfg.pageSizeParam, cfg.pageParam = offset, limit, ""
 	}
-	if p.pageSize > maxPageSize {
-		p.pageSize = maxPageSize
+}
+
+// WithRejectOversized makes Parse return an error for page sizes above the
+// maximum instead of reducing them to the maximum.
+func WithRejectOversized() Option {
+	return func(cfg *config) {
+		cfg.rejectOversized = true
 	}
+}
 
+func New[T any](page, pageSize int, opts ...Option) Paginator[T] {
+	cfg := newConfig(opts)
+	p := Paginator[T]{
+		cfg:      cfg,
+		page:     max(page, 1),
+		pageSize: cfg.clampPageSize(pageSize),
+	}
+	p.offset = (p.page - 1) * p.pageSize
 	return p
 }
 
+// NewFromOffset returns a Paginator for pageSize items starting at offset.
+func NewFromOffset[T any](offset, pageSize int, opts ...Option) Paginator[T] {
+	cfg := newConfig(opts)
+	return Paginator[T]{
+		cfg:      cfg,
+		pageSize: cfg.clampPageSize(pageSize),
+		offset:   max(offset, 0),
+	}
+}
+
+func (cfg config) clampPageSize(n int) int {
+	if n <= 0 {
+		return cfg.defaultPageSize
+	}
+	return min(n, cfg.maxPageSize)
+}
+
 func (p Paginator[T]) Offset() int {
-	return (p.page - 1) * p.pageSize
+	return p.offset
 }
 
 func (p Paginator[T]) Limit() int {
 	return p.pageSize + 1
 }
 
+// Page returns the page number, or 0 if p selects a page by offset.
 func (p Paginator[T]) Page() int {
 	return p.page
 }
@@ -64,45 +171,165 @@ func (p Paginator[T]) PageSize() int {
 }
 
 func (p Paginator[T]) Paginate(items []T) Result[T] {
-	if len(items) > p.pageSize {
+	if len(items) <= p.pageSize {
 		return Result[T]{
-			Items:    items[:p.pageSize],
-			NextPage: p.page + 1,
+			Items: items,
 		}
 	}
-	return Result[T]{
-		Items: items,
+
+	result := Result[T]{Items: items[:p.pageSize]}
+	if p.page > 0 {
+		result.NextPage = p.page + 1
+	} else {
+		result.NextOffset = p.offset + p.pageSize
 	}
+	return result
 }
 
-func (r Result[T]) HasNextPage() bool {
-	return r.NextPage > 0
+// PaginateWithTotal is like Paginate but also reports the total number of
+// items, e.g. from a separate COUNT(*) query. items may hold up to Limit
+// rows.
+func (p Paginator[T]) PaginateWithTotal(items []T, total int) Result[T] {
+	result := p.Paginate(items)
+
+	page := p.page
+	if page == 0 {
+		page = p.offset/p.pageSize + 1
+	}
+	totals := Totals{
+		TotalItems: total,
+		TotalPages: (total + p.pageSize - 1) / p.pageSize,
+		Page:       page,
+		HasMore:    p.offset+len(result.Items) < total,
+	}
+	if page > 1 {
+		totals.PrevPage = page - 1
+	}
+	result.Totals = &totals
+
+	if totals.HasMore && !result.HasNextPage() {
+		if p.page > 0 {
+			result.NextPage = p.page + 1
+		} else {
+			result.NextOffset = p.offset + p.pageSize
+		}
+	}
+	return result
 }
 
-func Parse[T any](q url.Values) (*Paginator[T], error) {
-	var page, pageSize int
-	var err error
+// PaginateCounted is like PaginateWithTotal for rows selecting
+// TotalCountColumn, whose value totalCount returns. A page past the end has
+// no rows to carry the count, so if items is empty the total is unknown and
+// the result has no Totals, as with Paginate.
+func (p Paginator[T]) PaginateCounted(items []T, totalCount func(T) int) Result[T] {
+	if len(items) == 0 {
+		return p.Paginate(items)
+	}
+	return p.PaginateWithTotal(items, totalCount(items[0]))
+}
 
-	if pageParam := q.Get("page"); pageParam != "" {
-		page, err = strconv.Atoi(pageParam)
-		if err != nil {
-			return nil, errors.New("query parameter `page` must be an integer, got string")
+// Links returns the URLs of the pages adjacent to result, which p returned
+// for r, using the query parameters of the options p was created with. The
+// last page is only known if result has Totals.
+func (p Paginator[T]) Links(r *http.Request, result Result[T]) pagination.Links {
+	cfg := p.cfg
+	pageSize := strconv.Itoa(p.pageSize)
+
+	if p.page == 0 {
+		offsetParam := cmp.Or(cfg.offsetParam, "offset")
+		link := func(offset int) string {
+			return pagination.URL(r, url.Values{
+				offsetParam:       {strconv.Itoa(offset)},
+				cfg.pageSizeParam: {pageSize},
+			})
 		}
-		if page <= 0 {
-			return nil, errors.New("query parameter `page` must be non-negative")
+
+		links := pagination.Links{First: link(0)}
+		if p.offset > 0 {
+			links.Prev = link(max(p.offset-p.pageSize, 0))
+		}
+		if result.NextOffset > 0 {
+			links.Next = link(result.NextOffset)
+		}
+		if result.Totals != nil {
+			links.Last = link(max(result.TotalPages-1, 0) * p.pageSize)
 		}
+		return links
+	}
+
+	link := func(page int) string {
+		return pagination.URL(r, url.Values{
+			cfg.pageParam:     {strconv.Itoa(page)},
+			cfg.pageSizeParam: {pageSize},
+		})
 	}
 
-	if pageSizeParam := q.Get("page_size"); pageSizeParam != "" {
-		pageSize, err = strconv.Atoi(pageSizeParam)
+	links := pagination.Links{First: link(1)}
+	if p.page > 1 {
+		links.Prev = link(p.page - 1)
+	}
+	if result.NextPage > 0 {
+		links.Next = link(result.NextPage)
+	}
+	if result.Totals != nil {
+		links.Last = link(max(result.TotalPages, 1))
+	}
+	return links
+}
+
+func (r Result[T]) HasNextPage() bool {
+	return r.NextPage > 0 || r.NextOffset > 0
+}
+
+func Parse[T any](q url.Values, opts ...Option) (*Paginator[T], error) {
+	cfg := newConfig(opts)
+
+	pageSize, err := parseParam(q, cfg.pageSizeParam, 1)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.rejectOversized && pageSize > cfg.maxPageSize {
+		return nil, &ParamError{
+			Param:  cfg.pageSizeParam,
+			Value:  q.Get(cfg.pageSizeParam),
+			Reason: ReasonTooLarge,
+			Limit:  cfg.maxPageSize,
+		}
+	}
+
+	var p Paginator[T]
+	if cfg.offsetParam != "" {
+		offset, err := parseParam(q, cfg.offsetParam, 0)
 		if err != nil {
-			return nil, errors.New("query parameter `page_size` must be an integer, got string")
+			return nil, err
 		}
-		if pageSize <= 0 {
-			return nil, errors.New("query parameter `page_size` must be non-negative")
+		p = NewFromOffset[T](offset, pageSize, opts...)
+	} else {
+		page, err := parseParam(q, cfg.pageParam, 1)
+		if err != nil {
+			return nil, err
 		}
+		p = New[T](page, pageSize, opts...)
 	}
-
-	p := New[T](page, pageSize)
 	return &p, nil
 }
+
+// parseParam returns the integer value of param, or 0 if it is missing.
+func parseParam(q url.Values, param string, minValue int) (int, error) {
+	s := q.Get(param)
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, &ParamError{Param: param, Value: s, Reason: ReasonNotInteger}
+	}
+	if n < minValue {
+		reason := ReasonNegative
+		if minValue > 0 {
+			reason = ReasonNotPositive
+		}
+		return 0, &ParamError{Param: param, Value: s, Reason: reason}
+	}
+	return n, nil
+}
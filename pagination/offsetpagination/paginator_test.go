@@ -1,10 +1,13 @@
 package offsetpagination
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
+	"github.com/HGV/x/pagination"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,6 +35,7 @@ func TestNew(t *testing.T) {
 			expected: Paginator[any]{
 				page:     3,
 				pageSize: 100,
+				offset:   200,
 			},
 		},
 		{
@@ -39,12 +43,14 @@ func TestNew(t *testing.T) {
 			expected: Paginator[any]{
 				page:     3,
 				pageSize: 100,
+				offset:   200,
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
+			tt.expected.cfg = defaultConfig()
 			assert.Equal(t, tt.expected, tt.p)
 		})
 	}
@@ -105,3 +111,256 @@ func TestParse(t *testing.T) {
 	assert.Equal(t, p.page, 3)
 	assert.Equal(t, p.pageSize, 100)
 }
+
+func TestNewWithOptions(t *testing.T) {
+	tests := []struct {
+		p        Paginator[any]
+		expected Paginator[any]
+	}{
+		{
+			p: New[any](1, 0, WithDefaultPageSize(20)),
+			expected: Paginator[any]{
+				cfg:      newConfig([]Option{WithDefaultPageSize(20)}),
+				page:     1,
+				pageSize: 20,
+			},
+		},
+		{
+			p: New[any](2, 800, WithMaxPageSize(500)),
+			expected: Paginator[any]{
+				cfg:      newConfig([]Option{WithMaxPageSize(500)}),
+				page:     2,
+				pageSize: 500,
+				offset:   500,
+			},
+		},
+		{
+			p: New[any](1, 0, WithDefaultPageSize(200)),
+			expected: Paginator[any]{
+				cfg:      newConfig([]Option{WithDefaultPageSize(200)}),
+				page:     1,
+				pageSize: maxPageSize,
+			},
+		},
+		{
+			p: NewFromOffset[any](30, 10),
+			expected: Paginator[any]{
+				cfg:      defaultConfig(),
+				pageSize: 10,
+				offset:   30,
+			},
+		},
+		{
+			p: NewFromOffset[any](-1, 0),
+			expected: Paginator[any]{
+				cfg:      defaultConfig(),
+				pageSize: defaultPageSize,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.p)
+		})
+	}
+}
+
+func TestPaginateFromOffset(t *testing.T) {
+	p := NewFromOffset[int](30, 10)
+	items := make([]int, 11)
+
+	result := p.Paginate(items)
+	assert.Len(t, result.Items, 10)
+	assert.Zero(t, result.NextPage)
+	assert.Equal(t, 40, result.NextOffset)
+	assert.True(t, result.HasNextPage())
+
+	result = p.Paginate(items[:5])
+	assert.False(t, result.HasNextPage())
+}
+
+func TestParseWithOptions(t *testing.T) {
+	tests := []struct {
+		query            string
+		opts             []Option
+		expected         Paginator[any]
+		expectedErrorMsg string
+	}{
+		{
+			query:    "p=2&per_page=20",
+			opts:     []Option{WithPageParams("p", "per_page")},
+			expected: Paginator[any]{page: 2, pageSize: 20, offset: 20},
+		},
+		{
+			query:    "offset=15&limit=5",
+			opts:     []Option{WithOffsetParams("offset", "limit")},
+			expected: Paginator[any]{pageSize: 5, offset: 15},
+		},
+		{
+			query:    "offset=0",
+			opts:     []Option{WithOffsetParams("offset", "limit")},
+			expected: Paginator[any]{pageSize: defaultPageSize},
+		},
+		{
+			query:    "page_size=500",
+			opts:     []Option{WithMaxPageSize(500)},
+			expected: Paginator[any]{page: 1, pageSize: 500},
+		},
+		{
+			query:    "page_size=200",
+			expected: Paginator[any]{page: 1, pageSize: maxPageSize},
+		},
+		{
+			query:            "page_size=200",
+			opts:             []Option{WithRejectOversized()},
+			expectedErrorMsg: "query parameter `page_size` must not exceed 100, got \"200\"",
+		},
+		{
+			query:            "offset=-1",
+			opts:             []Option{WithOffsetParams("offset", "limit")},
+			expectedErrorMsg: "query parameter `offset` must be non-negative, got \"-1\"",
+		},
+		{
+			query:            "limit=abc",
+			opts:             []Option{WithOffsetParams("offset", "limit")},
+			expectedErrorMsg: "query parameter `limit` must be an integer, got \"abc\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			p, err := Parse[any](q, tt.opts...)
+			if tt.expectedErrorMsg != "" {
+				assert.EqualError(t, err, tt.expectedErrorMsg)
+				return
+			}
+			assert.NoError(t, err)
+			tt.expected.cfg = newConfig(tt.opts)
+			assert.Equal(t, tt.expected, *p)
+		})
+	}
+}
+
+func TestPaginateWithTotal(t *testing.T) {
+	items := make([]int, 11)
+
+	tests := []struct {
+		p              Paginator[int]
+		items          []int
+		total          int
+		expectedTotals Totals
+		expectedNext   int
+	}{
+		{
+			p:              New[int](1, 10),
+			items:          items,
+			total:          25,
+			expectedTotals: Totals{TotalItems: 25, TotalPages: 3, Page: 1, HasMore: true},
+			expectedNext:   2,
+		},
+		{
+			p:              New[int](2, 10),
+			items:          items[:10],
+			total:          25,
+			expectedTotals: Totals{TotalItems: 25, TotalPages: 3, Page: 2, PrevPage: 1, HasMore: true},
+			expectedNext:   3,
+		},
+		{
+			p:              New[int](3, 10),
+			items:          items[:5],
+			total:          25,
+			expectedTotals: Totals{TotalItems: 25, TotalPages: 3, Page: 3, PrevPage: 2},
+		},
+		{
+			p:              New[int](1, 10),
+			total:          0,
+			expectedTotals: Totals{Page: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			result := tt.p.PaginateWithTotal(tt.items, tt.total)
+			assert.Equal(t, tt.expectedTotals, *result.Totals)
+			assert.Equal(t, tt.expectedNext, result.NextPage)
+		})
+	}
+}
+
+func TestPaginateCounted(t *testing.T) {
+	type row struct {
+		ID         int
+		TotalCount int
+	}
+	rows := []row{{1, 12}, {2, 12}}
+
+	result := NewFromOffset[row](10, 5).PaginateCounted(rows, func(r row) int { return r.TotalCount })
+	assert.Equal(t, Totals{TotalItems: 12, TotalPages: 3, Page: 3, PrevPage: 2}, *result.Totals)
+	assert.False(t, result.HasNextPage())
+
+	// Past the end there is no row to read the count from.
+	result = NewFromOffset[row](20, 5).PaginateCounted(nil, func(r row) int { return r.TotalCount })
+	assert.Nil(t, result.Totals)
+	assert.Empty(t, result.Items)
+}
+
+func TestResultJSON(t *testing.T) {
+	p := New[int](2, 2)
+
+	data, err := json.Marshal(p.Paginate([]int{1, 2, 3}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"items":[1,2],"next_page":3}`, string(data))
+
+	data, err = json.Marshal(p.PaginateWithTotal([]int{1, 2, 3}, 5))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"items":[1,2],"next_page":3,"total_items":5,"total_pages":3,"page":2,"prev_page":1,"has_more":true}`, string(data))
+}
+
+func TestLinks(t *testing.T) {
+	t.Run("pages", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/items?q=foo&page=2&page_size=10", nil)
+		p, _ := Parse[int](r.URL.Query())
+		result := p.PaginateWithTotal(make([]int, 11), 35)
+
+		assert.Equal(t, pagination.Links{
+			First: "http://example.com/items?page=1&page_size=10&q=foo",
+			Prev:  "http://example.com/items?page=1&page_size=10&q=foo",
+			Next:  "http://example.com/items?page=3&page_size=10&q=foo",
+			Last:  "http://example.com/items?page=4&page_size=10&q=foo",
+		}, p.Links(r, result))
+	})
+
+	t.Run("first page without totals", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/items", nil)
+		p, _ := Parse[int](r.URL.Query())
+		result := p.Paginate(make([]int, 10))
+
+		assert.Equal(t, pagination.Links{
+			First: "http://example.com/items?page=1&page_size=50",
+		}, p.Links(r, result))
+	})
+
+	t.Run("offsets", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://example.com/items?offset=5&limit=10", nil)
+		p, _ := Parse[int](r.URL.Query(), WithOffsetParams("offset", "limit"))
+		result := p.PaginateWithTotal(make([]int, 11), 35)
+
+		assert.Equal(t, pagination.Links{
+			First: "http://example.com/items?limit=10&offset=0",
+			Prev:  "http://example.com/items?limit=10&offset=0",
+			Next:  "http://example.com/items?limit=10&offset=15",
+			Last:  "http://example.com/items?limit=10&offset=30",
+		}, p.Links(r, result))
+	})
+}
+
+func TestResultJSONWithLinks(t *testing.T) {
+	result := New[int](1, 2).Paginate([]int{1})
+	result.Links = &pagination.Links{First: "http://example.com/items?page=1"}
+
+	data, err := json.Marshal(result)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"items":[1],"links":{"first":"http://example.com/items?page=1"}}`, string(data))
+}
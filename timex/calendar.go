@@ -0,0 +1,268 @@
+package timex
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// Calendar decides which days are business days.
+type Calendar interface {
+	IsBusinessDay(d Date) bool
+}
+
+type Holiday struct {
+	Date Date
+	Name string
+}
+
+// HolidayRule computes the date of a recurring holiday.
+type HolidayRule interface {
+	// Holiday returns the holiday falling into year. The boolean result is
+	// false if the holiday does not occur in that year.
+	Holiday(year int) (Holiday, bool)
+}
+
+// FixedHoliday is a holiday on the same month and day every year.
+type FixedHoliday struct {
+	Name  string
+	Month time.Month
+	Day   int
+}
+
+func (h FixedHoliday) Holiday(year int) (Holiday, bool) {
+	if h.Day < 1 || h.Day > daysIn(h.Month, year) {
+		return Holiday{}, false
+	}
+	return Holiday{Date: Date{year, h.Month, h.Day}, Name: h.Name}, true
+}
+
+// EasterHoliday is a movable feast a fixed number of days after (or before,
+// if negative) Easter Sunday, e.g. 1 for Easter Monday or 50 for Pentecost
+// Monday.
+type EasterHoliday struct {
+	Name string
+	Days int
+}
+
+func (h EasterHoliday) Holiday(year int) (Holiday, bool) {
+	return Holiday{Date: Easter(year).AddDays(h.Days), Name: h.Name}, true
+}
+
+// NthWeekdayHoliday is a holiday on the n-th weekday of a month, e.g. the
+// second Sunday of May. A negative N counts from the end of the month, so -1
+// is the last such weekday.
+type NthWeekdayHoliday struct {
+	Name    string
+	Month   time.Month
+	Weekday time.Weekday
+	N       int
+}
+
+func (h NthWeekdayHoliday) Holiday(year int) (Holiday, bool) {
+	d, ok := nthWeekdayOfMonth(YearMonth{year, h.Month}, h.Weekday, h.N)
+	if !ok {
+		return Holiday{}, false
+	}
+	return Holiday{Date: d, Name: h.Name}, true
+}
+
+// HolidayInYears limits a rule to the years from through until, both
+// inclusive. A zero bound is open.
+func HolidayInYears(rule HolidayRule, from, until int) HolidayRule {
+	return yearsHolidayRule{rule, from, until}
+}
+
+type yearsHolidayRule struct {
+	rule        HolidayRule
+	from, until int
+}
+
+func (h yearsHolidayRule) Holiday(year int) (Holiday, bool) {
+	if (h.from != 0 && year < h.from) || (h.until != 0 && year > h.until) {
+		return Holiday{}, false
+	}
+	return h.rule.Holiday(year)
+}
+
+// Easter returns the date of Easter Sunday in the Gregorian calendar.
+func Easter(year int) Date {
+	// Anonymous Gregorian algorithm (Meeus/Jones/Butcher).
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return Date{year, time.Month(month), day}
+}
+
+var (
+	// ItalyHolidays are the national public holidays of Italy.
+	ItalyHolidays = []HolidayRule{
+		FixedHoliday{"Capodanno", time.January, 1},
+		FixedHoliday{"Epifania", time.January, 6},
+		EasterHoliday{"Pasqua", 0},
+		EasterHoliday{"Lunedì dell'Angelo", 1},
+		FixedHoliday{"Festa della Liberazione", time.April, 25},
+		FixedHoliday{"Festa del Lavoro", time.May, 1},
+		FixedHoliday{"Festa della Repubblica", time.June, 2},
+		FixedHoliday{"Ferragosto", time.August, 15},
+		HolidayInYears(FixedHoliday{"San Francesco d'Assisi", time.October, 4}, 2026, 0),
+		FixedHoliday{"Ognissanti", time.November, 1},
+		FixedHoliday{"Immacolata Concezione", time.December, 8},
+		FixedHoliday{"Natale", time.December, 25},
+		FixedHoliday{"Santo Stefano", time.December, 26},
+	}
+
+	// SouthTyrolHolidays are the public holidays of the province of South
+	// Tyrol, i.e. the Italian ones plus Pentecost Monday.
+	SouthTyrolHolidays = append(slices.Clip(ItalyHolidays),
+		EasterHoliday{"Pfingstmontag", 50},
+	)
+)
+
+// RuleCalendar is a Calendar where every day is a business day unless it falls
+// on a weekend day or a holiday produced by one of its rules.
+type RuleCalendar struct {
+	Weekend DaysOfWeek
+	Rules   []HolidayRule
+}
+
+var _ Calendar = RuleCalendar{}
+
+var (
+	ItalyCalendar      = NewRuleCalendar(Weekend(), ItalyHolidays...)
+	SouthTyrolCalendar = NewRuleCalendar(Weekend(), SouthTyrolHolidays...)
+)
+
+func NewRuleCalendar(weekend DaysOfWeek, rules ...HolidayRule) RuleCalendar {
+	return RuleCalendar{
+		Weekend: weekend,
+		Rules:   rules,
+	}
+}
+
+// With returns a copy of c with additional rules, e.g. a local patron saint's
+// day on top of a national calendar.
+func (c RuleCalendar) With(rules ...HolidayRule) RuleCalendar {
+	return RuleCalendar{
+		Weekend: c.Weekend,
+		Rules:   append(slices.Clip(c.Rules), rules...),
+	}
+}
+
+// Holidays returns the holidays in year sorted by date.
+func (c RuleCalendar) Holidays(year int) []Holiday {
+	var holidays []Holiday
+	for _, rule := range c.Rules {
+		if h, ok := rule.Holiday(year); ok {
+			holidays = append(holidays, h)
+		}
+	}
+	slices.SortStableFunc(holidays, func(a, b Holiday) int {
+		return a.Date.Compare(b.Date)
+	})
+	return holidays
+}
+
+// Holiday returns the holiday falling on d, if any.
+func (c RuleCalendar) Holiday(d Date) (Holiday, bool) {
+	for _, rule := range c.Rules {
+		if h, ok := rule.Holiday(d.Year); ok && h.Date == d {
+			return h, true
+		}
+	}
+	return Holiday{}, false
+}
+
+func (c RuleCalendar) IsBusinessDay(d Date) bool {
+	if c.Weekend.Has(d.Weekday()) {
+		return false
+	}
+	_, ok := c.Holiday(d)
+	return !ok
+}
+
+// ErrNoBusinessDays is returned when a calendar has no business day within a
+// year of the date searched from, e.g. because its weekend covers every day.
+var ErrNoBusinessDays = errors.New("calendar has no business days")
+
+// maxNonBusinessDays is the longest run of days searched for a business day.
+// Weekends and yearly holidays repeat within a year, so a calendar without
+// a business day in that time has none at all.
+const maxNonBusinessDays = 366
+
+// NextBusinessDay returns d if it is a business day and the first business
+// day after d otherwise.
+func NextBusinessDay(cal Calendar, d Date) (Date, error) {
+	if cal.IsBusinessDay(d) {
+		return d, nil
+	}
+	return stepBusinessDay(cal, d, 1)
+}
+
+// AddBusinessDays returns the date n business days after d, or before d if n
+// is negative. d itself does not count, so adding one business day on a
+// Friday yields the following Monday unless that is a holiday.
+func AddBusinessDays(cal Calendar, d Date, n int) (Date, error) {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		var err error
+		if d, err = stepBusinessDay(cal, d, step); err != nil {
+			return Date{}, err
+		}
+	}
+	return d, nil
+}
+
+// stepBusinessDay returns the first business day after d, or before d if step
+// is negative.
+func stepBusinessDay(cal Calendar, d Date, step int) (Date, error) {
+	for range maxNonBusinessDays {
+		d = d.AddDays(step)
+		if cal.IsBusinessDay(d) {
+			return d, nil
+		}
+	}
+	return Date{}, ErrNoBusinessDays
+}
+
+// BusinessDaysBetween returns the number of business days in r, counting
+// both bounds. It returns an error if r is not bounded on both sides.
+func BusinessDaysBetween(cal Calendar, r DateRange) (int, error) {
+	if !r.hasStart() || !r.hasEnd() {
+		return 0, errors.New("cannot count business days in an unbounded date range")
+	}
+
+	var n int
+	for d := range r.Days() {
+		if cal.IsBusinessDay(d) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func nthWeekdayOfMonth(m YearMonth, weekday time.Weekday, n int) (Date, bool) {
+	var d Date
+	switch {
+	case n > 0:
+		first := m.FirstDay()
+		d = first.AddDays((int(weekday)-int(first.Weekday())+7)%7 + (n-1)*7)
+	case n < 0:
+		last := m.LastDay()
+		d = last.AddDays(-((int(last.Weekday())-int(weekday)+7)%7 + (-n-1)*7))
+	default:
+		return Date{}, false
+	}
+	return d, m.Contains(d)
+}
@@ -0,0 +1,190 @@
+package timex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year int
+		want Date
+	}{
+		{1961, Date{1961, 4, 2}},
+		{2000, Date{2000, 4, 23}},
+		{2008, Date{2008, 3, 23}},
+		{2011, Date{2011, 4, 24}},
+		{2024, Date{2024, 3, 31}},
+		{2025, Date{2025, 4, 20}},
+		{2026, Date{2026, 4, 5}},
+		{2038, Date{2038, 4, 25}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Easter(tt.year), tt.year)
+	}
+}
+
+func TestHolidayRules(t *testing.T) {
+	tests := []struct {
+		desc string
+		rule HolidayRule
+		year int
+		want Date
+		ok   bool
+	}{
+		{"fixed", FixedHoliday{"Natale", time.December, 25}, 2025, Date{2025, 12, 25}, true},
+		{"fixed leap day in leap year", FixedHoliday{"", time.February, 29}, 2024, Date{2024, 2, 29}, true},
+		{"fixed leap day in non-leap year", FixedHoliday{"", time.February, 29}, 2025, Date{}, false},
+		{"easter monday", EasterHoliday{"Ostermontag", 1}, 2025, Date{2025, 4, 21}, true},
+		{"good friday", EasterHoliday{"Karfreitag", -2}, 2025, Date{2025, 4, 18}, true},
+		{"pentecost monday", EasterHoliday{"Pfingstmontag", 50}, 2025, Date{2025, 6, 9}, true},
+		{"second sunday of may", NthWeekdayHoliday{"", time.May, time.Sunday, 2}, 2025, Date{2025, 5, 11}, true},
+		{"first monday of september", NthWeekdayHoliday{"", time.September, time.Monday, 1}, 2025, Date{2025, 9, 1}, true},
+		{"last monday of may", NthWeekdayHoliday{"", time.May, time.Monday, -1}, 2025, Date{2025, 5, 26}, true},
+		{"last saturday of may", NthWeekdayHoliday{"", time.May, time.Saturday, -1}, 2025, Date{2025, 5, 31}, true},
+		{"fifth friday missing", NthWeekdayHoliday{"", time.February, time.Friday, 5}, 2025, Date{}, false},
+		{"zeroth weekday", NthWeekdayHoliday{"", time.May, time.Monday, 0}, 2025, Date{}, false},
+		{"before first year", HolidayInYears(FixedHoliday{"", time.October, 4}, 2026, 0), 2025, Date{}, false},
+		{"from first year", HolidayInYears(FixedHoliday{"", time.October, 4}, 2026, 0), 2026, Date{2026, 10, 4}, true},
+		{"after last year", HolidayInYears(FixedHoliday{"", time.October, 4}, 0, 2020), 2021, Date{}, false},
+	}
+
+	for _, tt := range tests {
+		h, ok := tt.rule.Holiday(tt.year)
+		assert.Equal(t, tt.ok, ok, tt.desc)
+		assert.Equal(t, tt.want, h.Date, tt.desc)
+	}
+}
+
+func TestRuleCalendarHolidays(t *testing.T) {
+	holidays := ItalyCalendar.Holidays(2025)
+	assert.Len(t, holidays, 12)
+	assert.Equal(t, Holiday{Date{2025, 1, 1}, "Capodanno"}, holidays[0])
+	assert.Equal(t, Holiday{Date{2025, 4, 21}, "Lunedì dell'Angelo"}, holidays[3])
+	assert.Equal(t, Holiday{Date{2025, 12, 26}, "Santo Stefano"}, holidays[11])
+
+	assert.Len(t, ItalyCalendar.Holidays(2026), 13)
+	assert.Len(t, SouthTyrolCalendar.Holidays(2026), 14)
+}
+
+func TestRuleCalendarIsBusinessDay(t *testing.T) {
+	tests := []struct {
+		desc       string
+		date       Date
+		italy      bool
+		southTyrol bool
+	}{
+		{"regular tuesday", Date{2025, 7, 1}, true, true},
+		{"saturday", Date{2025, 7, 5}, false, false},
+		{"sunday", Date{2025, 7, 6}, false, false},
+		{"new year", Date{2025, 1, 1}, false, false},
+		{"easter monday", Date{2025, 4, 21}, false, false},
+		{"republic day", Date{2025, 6, 2}, false, false},
+		{"pentecost monday", Date{2025, 6, 9}, true, false},
+		{"st francis before 2026", Date{2024, 10, 4}, true, true},
+		{"st francis since 2026", Date{2027, 10, 4}, false, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.italy, ItalyCalendar.IsBusinessDay(tt.date), tt.desc)
+		assert.Equal(t, tt.southTyrol, SouthTyrolCalendar.IsBusinessDay(tt.date), tt.desc)
+	}
+}
+
+func TestRuleCalendarWith(t *testing.T) {
+	merano := SouthTyrolCalendar.With(FixedHoliday{"Patron", time.July, 1})
+
+	assert.False(t, merano.IsBusinessDay(Date{2025, 7, 1}))
+	assert.True(t, SouthTyrolCalendar.IsBusinessDay(Date{2025, 7, 1}))
+	assert.Len(t, SouthTyrolCalendar.Rules, len(SouthTyrolHolidays))
+
+	h, ok := merano.Holiday(Date{2025, 7, 1})
+	assert.True(t, ok)
+	assert.Equal(t, "Patron", h.Name)
+}
+
+func TestNextBusinessDay(t *testing.T) {
+	tests := []struct {
+		date Date
+		want Date
+	}{
+		{Date{2025, 7, 1}, Date{2025, 7, 1}},
+		{Date{2025, 7, 5}, Date{2025, 7, 7}},
+		// Christmas 2025 is a Thursday, so the next business day is Monday.
+		{Date{2025, 12, 25}, Date{2025, 12, 29}},
+	}
+
+	for _, tt := range tests {
+		got, err := NextBusinessDay(ItalyCalendar, tt.date)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.date.String())
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	tests := []struct {
+		desc string
+		date Date
+		n    int
+		want Date
+	}{
+		{"zero", Date{2025, 7, 5}, 0, Date{2025, 7, 5}},
+		{"within a week", Date{2025, 7, 1}, 2, Date{2025, 7, 3}},
+		{"over a weekend", Date{2025, 7, 4}, 1, Date{2025, 7, 7}},
+		{"from a saturday", Date{2025, 7, 5}, 1, Date{2025, 7, 7}},
+		{"over christmas", Date{2025, 12, 23}, 3, Date{2025, 12, 30}},
+		{"over easter", Date{2025, 4, 17}, 2, Date{2025, 4, 22}},
+		{"backwards over a weekend", Date{2025, 7, 7}, -1, Date{2025, 7, 4}},
+		{"backwards over easter", Date{2025, 4, 22}, -2, Date{2025, 4, 17}},
+	}
+
+	for _, tt := range tests {
+		got, err := AddBusinessDays(ItalyCalendar, tt.date, tt.n)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.desc)
+	}
+
+	got, err := AddBusinessDays(SouthTyrolCalendar, Date{2025, 6, 6}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, Date{2025, 6, 10}, got)
+}
+
+func TestNoBusinessDays(t *testing.T) {
+	cal := RuleCalendar{Weekend: DaysOfWeek{true, true, true, true, true, true, true}}
+
+	_, err := NextBusinessDay(cal, Date{2025, 7, 5})
+	assert.ErrorIs(t, err, ErrNoBusinessDays)
+
+	_, err = AddBusinessDays(cal, Date{2025, 7, 5}, -1)
+	assert.ErrorIs(t, err, ErrNoBusinessDays)
+
+	n, err := BusinessDaysBetween(cal, YearMonth{2025, time.July}.DateRange())
+	assert.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	tests := []struct {
+		cal  Calendar
+		r    DateRange
+		want int
+	}{
+		{ItalyCalendar, YearMonth{2025, time.July}.DateRange(), 23},
+		{ItalyCalendar, YearMonth{2025, time.December}.DateRange(), 20},
+		{ItalyCalendar, YearMonth{2025, time.June}.DateRange(), 20},
+		{SouthTyrolCalendar, YearMonth{2025, time.June}.DateRange(), 19},
+		{ItalyCalendar, DateRange{Start: Date{2025, 7, 5}, End: Date{2025, 7, 6}}, 0},
+	}
+
+	for _, tt := range tests {
+		got, err := BusinessDaysBetween(tt.cal, tt.r)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.r.String())
+	}
+
+	_, err := BusinessDaysBetween(ItalyCalendar, DateRange{Start: Date{2025, 7, 5}, EndBound: Unbounded})
+	assert.Error(t, err)
+}
@@ -0,0 +1,200 @@
+package timex
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock tells the current time. Code depending on "now" should take a Clock
+// instead of calling time.Now so it can be tested with a FakeClock.
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	NewTimer(d time.Duration) Timer
+	After(d time.Duration) <-chan time.Time
+}
+
+// Timer is the Clock counterpart of time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type systemTimer struct {
+	t *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.t.C
+}
+
+func (t systemTimer) Stop() bool {
+	return t.t.Stop()
+}
+
+func (t systemTimer) Reset(d time.Duration) bool {
+	return t.t.Reset(d)
+}
+
+// FakeClock is a Clock that only moves when told to. Timers fire as soon as
+// the clock is set to or past their deadline.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+var _ Clock = &FakeClock{}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	c.schedule(t, d)
+	return t
+}
+
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// Set moves the clock to now and fires all timers due by then. The clock may
+// be set backwards, which does not fire any timers.
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+	c.timers = slices.DeleteFunc(c.timers, func(t *fakeTimer) bool {
+		if t.deadline.After(now) {
+			return false
+		}
+		select {
+		case t.c <- now:
+		default:
+		}
+		return true
+	})
+}
+
+// Advance moves the clock forward by d and fires all timers due by then.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// schedule adds t to the pending timers, or fires it right away if d is not
+// positive. c.mu must be held.
+func (c *FakeClock) schedule(t *fakeTimer, d time.Duration) {
+	t.deadline = c.now.Add(d)
+	if d <= 0 {
+		select {
+		case t.c <- c.now:
+		default:
+		}
+		return
+	}
+	c.timers = append(c.timers, t)
+}
+
+// unschedule removes t from the pending timers and reports whether it was
+// pending. c.mu must be held.
+func (c *FakeClock) unschedule(t *fakeTimer) bool {
+	i := slices.Index(c.timers, t)
+	if i < 0 {
+		return false
+	}
+	c.timers = slices.Delete(c.timers, i, i+1)
+	return true
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	c        chan time.Time
+	deadline time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.unschedule(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	active := t.clock.unschedule(t)
+	t.clock.schedule(t, d)
+	return active
+}
+
+type clockContextKey struct{}
+
+// WithClock returns a copy of ctx carrying c.
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockContextKey{}, c)
+}
+
+// ClockFromContext returns the Clock carried by ctx, or SystemClock if there
+// is none.
+func ClockFromContext(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockContextKey{}).(Clock); ok {
+		return c
+	}
+	return SystemClock
+}
+
+// TodayIn returns the current date in loc, e.g. the hotel's time zone,
+// according to c.
+func TodayIn(c Clock, loc *time.Location) Date {
+	return NewDateFromTime(c.Now().In(loc))
+}
+
+// TodayContext returns the current date in loc according to the Clock carried
+// by ctx.
+func TodayContext(ctx context.Context, loc *time.Location) Date {
+	return TodayIn(ClockFromContext(ctx), loc)
+}
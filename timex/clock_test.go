@@ -0,0 +1,98 @@
+package timex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	assert.Equal(t, start, c.Now())
+	c.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour), c.Now())
+	assert.Equal(t, time.Hour, c.Since(start))
+
+	c.Set(start)
+	assert.Equal(t, start, c.Now())
+}
+
+func TestFakeClockTimers(t *testing.T) {
+	start := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	timer := c.NewTimer(time.Minute)
+	after := c.After(2 * time.Minute)
+	stopped := c.NewTimer(time.Minute)
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop())
+
+	c.Advance(59 * time.Second)
+	assertNotFired(t, timer.C())
+	assertNotFired(t, after)
+
+	c.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-timer.C())
+	assertNotFired(t, after)
+	assertNotFired(t, stopped.C())
+	assert.False(t, timer.Stop())
+
+	c.Advance(5 * time.Minute)
+	assert.Equal(t, start.Add(6*time.Minute), <-after)
+
+	assert.False(t, timer.Reset(time.Minute))
+	assert.True(t, timer.Reset(2*time.Minute))
+	c.Advance(time.Minute)
+	assertNotFired(t, timer.C())
+	c.Advance(time.Minute)
+	assert.Equal(t, start.Add(8*time.Minute), <-timer.C())
+
+	assert.Equal(t, start.Add(8*time.Minute), <-c.After(0))
+}
+
+func assertNotFired(t *testing.T, c <-chan time.Time) {
+	t.Helper()
+	select {
+	case v := <-c:
+		t.Errorf("timer fired unexpectedly at %s", v)
+	default:
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	now := SystemClock.Now()
+	assert.False(t, now.Before(before))
+
+	timer := SystemClock.NewTimer(time.Millisecond)
+	<-timer.C()
+	assert.False(t, timer.Stop())
+}
+
+func TestClockFromContext(t *testing.T) {
+	assert.Equal(t, SystemClock, ClockFromContext(context.Background()))
+
+	c := NewFakeClock(time.Date(2025, 7, 1, 22, 30, 0, 0, time.UTC))
+	ctx := WithClock(context.Background(), c)
+	assert.Same(t, c, ClockFromContext(ctx))
+
+	rome, err := time.LoadLocation("Europe/Rome")
+	assert.NoError(t, err)
+	assert.Equal(t, Date{2025, 7, 2}, TodayContext(ctx, rome))
+	assert.Equal(t, Date{2025, 7, 1}, TodayContext(ctx, time.UTC))
+}
+
+func TestTodayIn(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	assert.NoError(t, err)
+
+	c := NewFakeClock(time.Date(2025, 7, 1, 21, 30, 0, 0, time.UTC))
+	assert.Equal(t, Date{2025, 7, 1}, TodayIn(c, rome))
+	c.Advance(time.Hour)
+	assert.Equal(t, Date{2025, 7, 2}, TodayIn(c, rome))
+	assert.Equal(t, Date{2025, 7, 1}, TodayIn(c, time.UTC))
+}
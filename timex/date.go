@@ -51,6 +51,85 @@ func (d Date) DaysSince(s Date) (days int) {
 	return int(deltaUnix / 86400)
 }
 
+// AddMonths returns d plus n months. If the day does not exist in the target
+// month it is clamped to the month's last day, e.g. Jan 31 + 1 month is Feb 28
+// (or Feb 29 in a leap year).
+func (d Date) AddMonths(n int) Date {
+	m := d.YearMonth().AddMonths(n)
+	return Date{m.Year, m.Month, min(d.Day, daysIn(m.Month, m.Year))}
+}
+
+// AddMonthsOverflow returns d plus n months. Unlike AddMonths, a day that does
+// not exist in the target month overflows into the following month like
+// time.Time.AddDate does, e.g. Jan 31 + 1 month is Mar 3 (or Mar 2 in a leap
+// year).
+func (d Date) AddMonthsOverflow(n int) Date {
+	return NewDateFromTime(d.In(time.UTC).AddDate(0, n, 0))
+}
+
+// AddYears returns d plus n years, clamping Feb 29 to Feb 28 in non-leap
+// years.
+func (d Date) AddYears(n int) Date {
+	return d.AddMonths(n * 12)
+}
+
+// AddYearsOverflow returns d plus n years, turning Feb 29 into Mar 1 in
+// non-leap years.
+func (d Date) AddYearsOverflow(n int) Date {
+	return d.AddMonthsOverflow(n * 12)
+}
+
+// MonthsBetween returns the number of whole months from start to end, i.e.
+// the largest n for which start.AddMonths(n) is not after end. The result is
+// negative if end is before start.
+func MonthsBetween(start, end Date) int {
+	n := (end.Year-start.Year)*12 + int(end.Month-start.Month)
+	if n > 0 && start.AddMonths(n).After(end) {
+		n--
+	} else if n < 0 && start.AddMonths(n).Before(end) {
+		n++
+	}
+	return n
+}
+
+// YearsBetween returns the number of whole years from start to end, e.g. the
+// age on end of someone born on start. Someone born on Feb 29 turns a year
+// older on Feb 28 in non-leap years, consistent with AddYears.
+func YearsBetween(start, end Date) int {
+	return MonthsBetween(start, end) / 12
+}
+
+func (d Date) StartOfMonth() Date {
+	return d.YearMonth().FirstDay()
+}
+
+func (d Date) EndOfMonth() Date {
+	return d.YearMonth().LastDay()
+}
+
+// StartOfWeek returns the first day of the week containing d, where weeks
+// start on first.
+func (d Date) StartOfWeek(first time.Weekday) Date {
+	offset := (int(d.Weekday()) - int(first) + 7) % 7
+	return d.AddDays(-offset)
+}
+
+// EndOfWeek returns the last day of the week containing d, where weeks start
+// on first.
+func (d Date) EndOfWeek(first time.Weekday) Date {
+	return d.StartOfWeek(first).AddDays(6)
+}
+
+func (d Date) IsLeapYear() bool {
+	return daysIn(time.February, d.Year) == 29
+}
+
+// DayOfYear returns the day of the year, in the range [1,365] for non-leap
+// years and [1,366] in leap years.
+func (d Date) DayOfYear() int {
+	return d.In(time.UTC).YearDay()
+}
+
 func (d Date) Before(d2 Date) bool {
 	if d.Year != d2.Year {
 		return d.Year < d2.Year
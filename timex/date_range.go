@@ -1,30 +1,75 @@
 package timex
 
 import (
+	"encoding"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"iter"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// Bound describes the kind of an endpoint of a DateRange.
+type Bound uint8
+
+const (
+	// Bounded is an endpoint at a specific date.
+	Bounded Bound = iota
+	// Unbounded is an omitted endpoint, e.g. the upper bound of [2025-01-01,).
+	Unbounded
+	// Infinite is an endpoint at -infinity (lower) or infinity (upper).
+	Infinite
+)
+
+// DateRange is a range of days including both Start and End. Either endpoint
+// may be open, in which case StartBound or EndBound is set and the
+// corresponding date is ignored. Unbounded and Infinite endpoints contain the
+// same days; they only differ in how they are encoded. A range whose End is
+// before Start is empty.
 type DateRange struct {
-	Start Date
-	End   Date
+	Start      Date
+	End        Date
+	StartBound Bound
+	EndBound   Bound
 }
 
+// emptyDateRange is the DateRange scanned and parsed from an empty range.
+var emptyDateRange = DateRange{Start: Date{1, time.January, 2}, End: Date{1, time.January, 1}}
+
 func (d *DateRange) ScanNull() error {
 	return fmt.Errorf("cannot scan NULL into daterange")
 }
 
 func (d *DateRange) ScanBounds() (lowerTarget, upperTarget any) {
-	return &d.Start, &d.End
+	*d = DateRange{}
+	return &dateBound{&d.Start, &d.StartBound}, &dateBound{&d.End, &d.EndBound}
 }
 
 func (d *DateRange) SetBoundTypes(lower, upper pgtype.BoundType) error {
-	if lower == pgtype.Exclusive {
-		d.Start = d.Start.AddDays(1)
+	// pgx does not call ScanBounds for an empty range.
+	if lower == pgtype.Empty {
+		*d = emptyDateRange
+		return nil
+	}
+
+	switch lower {
+	case pgtype.Unbounded:
+		d.StartBound = Unbounded
+	case pgtype.Exclusive:
+		if d.StartBound == Bounded {
+			d.Start = d.Start.AddDays(1)
+		}
 	}
-	if upper == pgtype.Exclusive {
-		d.End = d.End.AddDays(-1)
+	switch upper {
+	case pgtype.Unbounded:
+		d.EndBound = Unbounded
+	case pgtype.Exclusive:
+		if d.EndBound == Bounded {
+			d.End = d.End.AddDays(-1)
+		}
 	}
 	return nil
 }
@@ -34,16 +79,446 @@ func (d DateRange) IsNull() bool {
 }
 
 func (d DateRange) BoundTypes() (lower, upper pgtype.BoundType) {
-	return pgtype.Inclusive, pgtype.Inclusive
+	if d.IsEmpty() {
+		return pgtype.Empty, pgtype.Empty
+	}
+
+	lower, upper = pgtype.Inclusive, pgtype.Inclusive
+	if d.StartBound == Unbounded {
+		lower = pgtype.Unbounded
+	}
+	if d.EndBound == Unbounded {
+		upper = pgtype.Unbounded
+	}
+	return lower, upper
 }
 
 func (d DateRange) Bounds() (lower, upper any) {
-	return d.Start, d.End
+	return boundValue(d.Start, d.StartBound, pgtype.NegativeInfinity),
+		boundValue(d.End, d.EndBound, pgtype.Infinity)
 }
 
 var _ pgtype.RangeScanner = &DateRange{}
 var _ pgtype.RangeValuer = DateRange{}
 
+// dateBound scans a range element into a Date and records whether it was
+// infinite.
+type dateBound struct {
+	date  *Date
+	bound *Bound
+}
+
+func (b *dateBound) ScanDate(v pgtype.Date) error {
+	if v.InfinityModifier != pgtype.Finite {
+		*b.date, *b.bound = Date{}, Infinite
+		return nil
+	}
+	*b.bound = Bounded
+	return b.date.ScanDate(v)
+}
+
+func boundValue(d Date, b Bound, infinity pgtype.InfinityModifier) any {
+	switch b {
+	case Unbounded:
+		return nil
+	case Infinite:
+		return pgtype.Date{InfinityModifier: infinity, Valid: true}
+	default:
+		return d
+	}
+}
+
+// String returns d in the Postgres range syntax with inclusive bounds, e.g.
+// [2025-01-01,2025-01-31], [2025-01-01,), [-infinity,2025-01-31] or empty.
+func (d DateRange) String() string {
+	if d.IsEmpty() {
+		return "empty"
+	}
+
+	var b strings.Builder
+	switch d.StartBound {
+	case Unbounded:
+		b.WriteString("(")
+	case Infinite:
+		b.WriteString("[-infinity")
+	default:
+		b.WriteString("[" + d.Start.String())
+	}
+	b.WriteString(",")
+	switch d.EndBound {
+	case Unbounded:
+		b.WriteString(")")
+	case Infinite:
+		b.WriteString("infinity]")
+	default:
+		b.WriteString(d.End.String() + "]")
+	}
+	return b.String()
+}
+
+// ParseDateRange parses a range either in the Postgres range syntax, e.g.
+// [2025-01-01,2025-01-31] or [2025-01-01,2025-02-01), or as an ISO 8601 time
+// interval with an inclusive end, e.g. 2025-01-01/2025-01-31. Open endpoints
+// are written as empty exclusive bounds or ±infinity in the Postgres syntax
+// and as ".." in the ISO 8601 syntax. As in Postgres, an empty bound is
+// unbounded whether it is inclusive or exclusive. An empty range is written
+// as empty.
+func ParseDateRange(s string) (DateRange, error) {
+	if s == "empty" {
+		return emptyDateRange, nil
+	}
+	if strings.Contains(s, "/") {
+		return parseISODateRange(s)
+	}
+	return parsePostgresDateRange(s)
+}
+
+func parsePostgresDateRange(s string) (DateRange, error) {
+	if len(s) < 3 {
+		return DateRange{}, fmt.Errorf("invalid date range %q", s)
+	}
+	first, last := s[0], s[len(s)-1]
+	lower, upper, ok := strings.Cut(s[1:len(s)-1], ",")
+	if !ok || (first != '[' && first != '(') || (last != ']' && last != ')') {
+		return DateRange{}, fmt.Errorf("invalid date range %q", s)
+	}
+
+	var d DateRange
+	var err error
+	switch {
+	case lower == "":
+		d.StartBound = Unbounded
+	case lower == "-infinity":
+		d.StartBound = Infinite
+	default:
+		if d.Start, err = ParseDate(lower); err != nil {
+			return DateRange{}, err
+		}
+		if first == '(' {
+			d.Start = d.Start.AddDays(1)
+		}
+	}
+	switch {
+	case upper == "":
+		d.EndBound = Unbounded
+	case upper == "infinity":
+		d.EndBound = Infinite
+	default:
+		if d.End, err = ParseDate(upper); err != nil {
+			return DateRange{}, err
+		}
+		if last == ')' {
+			d.End = d.End.AddDays(-1)
+		}
+	}
+	return d, nil
+}
+
+func parseISODateRange(s string) (DateRange, error) {
+	start, end, _ := strings.Cut(s, "/")
+
+	var d DateRange
+	var err error
+	if start == ".." || start == "" {
+		d.StartBound = Unbounded
+	} else if d.Start, err = ParseDate(start); err != nil {
+		return DateRange{}, err
+	}
+	if end == ".." || end == "" {
+		d.EndBound = Unbounded
+	} else if d.End, err = ParseDate(end); err != nil {
+		return DateRange{}, err
+	}
+	return d, nil
+}
+
+// MarshalText returns d as written by String. A bounded endpoint at the zero
+// Date has no text form, since it would read back as unbounded.
+func (d DateRange) MarshalText() ([]byte, error) {
+	if !d.IsEmpty() && ((d.hasStart() && d.Start.IsZero()) || (d.hasEnd() && d.End.IsZero())) {
+		return nil, fmt.Errorf("cannot marshal DateRange %s with a zero Date", d)
+	}
+	return []byte(d.String()), nil
+}
+
+func (d *DateRange) UnmarshalText(data []byte) error {
+	var err error
+	*d, err = ParseDateRange(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = DateRange{}
+var _ encoding.TextUnmarshaler = &DateRange{}
+
+// dateRangeJSON is the JSON object form of a DateRange. An unbounded endpoint
+// is null, an infinite one is "-infinity" or "infinity".
+type dateRangeJSON struct {
+	Start *string `json:"start"`
+	End   *string `json:"end"`
+}
+
+func (d DateRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dateRangeJSON{
+		Start: boundJSON(d.Start, d.StartBound, "-infinity"),
+		End:   boundJSON(d.End, d.EndBound, "infinity"),
+	})
+}
+
+// UnmarshalJSON accepts the object form produced by MarshalJSON as well as a
+// string in any format understood by ParseDateRange. Both keys of the object
+// form are required. A JSON null leaves d unchanged.
+func (d *DateRange) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return d.UnmarshalText([]byte(s))
+	}
+
+	start, end, err := unmarshalRangeJSON(data)
+	if err != nil {
+		return err
+	}
+
+	var r DateRange
+	if r.Start, r.StartBound, err = parseBoundJSON(start, "-infinity"); err != nil {
+		return err
+	}
+	if r.End, r.EndBound, err = parseBoundJSON(end, "infinity"); err != nil {
+		return err
+	}
+	*d = r
+	return nil
+}
+
+var _ json.Marshaler = DateRange{}
+var _ json.Unmarshaler = &DateRange{}
+
+func boundJSON(d Date, b Bound, infinity string) *string {
+	switch b {
+	case Unbounded:
+		return nil
+	case Infinite:
+		return &infinity
+	default:
+		s := d.String()
+		return &s
+	}
+}
+
+// unmarshalRangeJSON decodes the object form of a range, requiring both the
+// start and the end key. An endpoint explicitly set to null is returned as
+// nil.
+func unmarshalRangeJSON(data []byte) (start, end *string, err error) {
+	var v struct {
+		Start json.RawMessage `json:"start"`
+		End   json.RawMessage `json:"end"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, nil, err
+	}
+	if v.Start == nil || v.End == nil {
+		return nil, nil, errors.New("range requires both start and end")
+	}
+	if err := json.Unmarshal(v.Start, &start); err != nil {
+		return nil, nil, err
+	}
+	if err := json.Unmarshal(v.End, &end); err != nil {
+		return nil, nil, err
+	}
+	return start, end, nil
+}
+
+func parseBoundJSON(s *string, infinity string) (Date, Bound, error) {
+	switch {
+	case s == nil:
+		return Date{}, Unbounded, nil
+	case *s == infinity:
+		return Date{}, Infinite, nil
+	case *s == "":
+		// The zero Date, as written by MarshalJSON.
+		return Date{}, Bounded, nil
+	}
+	d, err := ParseDate(*s)
+	return d, Bounded, err
+}
+
+// IsEmpty reports whether d contains no days, i.e. both endpoints are bounded
+// and End is before Start.
+func (d DateRange) IsEmpty() bool {
+	return d.hasStart() && d.hasEnd() && d.End.Before(d.Start)
+}
+
+func (d DateRange) Contains(date Date) bool {
+	return (!d.hasStart() || !date.Before(d.Start)) &&
+		(!d.hasEnd() || !date.After(d.End))
+}
+
+func (d DateRange) ContainsRange(d2 DateRange) bool {
+	return compareStarts(d, d2) <= 0 && compareEnds(d, d2) >= 0
+}
+
+func (d DateRange) Overlaps(d2 DateRange) bool {
+	return startsNotAfterEnd(d, d2) && startsNotAfterEnd(d2, d)
+}
+
+// Adjacent reports whether d and d2 do not overlap but together cover a
+// contiguous run of days, i.e. one range ends the day before the other starts.
+func (d DateRange) Adjacent(d2 DateRange) bool {
+	return endsBeforeStart(d, d2) || endsBeforeStart(d2, d)
+}
+
+// Intersect returns the days contained in both d and d2. The boolean result
+// is false if the ranges do not overlap.
+func (d DateRange) Intersect(d2 DateRange) (DateRange, bool) {
+	if !d.Overlaps(d2) {
+		return DateRange{}, false
+	}
+	r := d
+	if compareStarts(d2, d) > 0 {
+		r.Start, r.StartBound = d2.Start, d2.StartBound
+	}
+	if compareEnds(d2, d) < 0 {
+		r.End, r.EndBound = d2.End, d2.EndBound
+	}
+	return r, true
+}
+
+// Union returns the smallest range containing both d and d2. The boolean
+// result is false if the ranges neither overlap nor are adjacent, since their
+// union cannot be expressed as a single range.
+func (d DateRange) Union(d2 DateRange) (DateRange, bool) {
+	if !d.Overlaps(d2) && !d.Adjacent(d2) {
+		return DateRange{}, false
+	}
+	r := d
+	if compareStarts(d2, d) < 0 {
+		r.Start, r.StartBound = d2.Start, d2.StartBound
+	}
+	if compareEnds(d2, d) > 0 {
+		r.End, r.EndBound = d2.End, d2.EndBound
+	}
+	return r, true
+}
+
+// Subtract returns the days of d not contained in d2. The result holds zero
+// ranges if d2 covers d, two ranges if d2 lies strictly inside d and one range
+// otherwise.
+func (d DateRange) Subtract(d2 DateRange) []DateRange {
+	if d2.IsEmpty() || !d.Overlaps(d2) {
+		return []DateRange{d}
+	}
+
+	var result []DateRange
+	if compareStarts(d, d2) < 0 {
+		r := d
+		r.End, r.EndBound = d2.Start.AddDays(-1), Bounded
+		result = append(result, r)
+	}
+	if compareEnds(d, d2) > 0 {
+		r := d
+		r.Start, r.StartBound = d2.End.AddDays(1), Bounded
+		result = append(result, r)
+	}
+	return result
+}
+
+// Days returns an iterator over every day in d in ascending order. It yields
+// nothing if d has an open start and never stops if d has an open end.
+func (d DateRange) Days() iter.Seq[Date] {
+	return d.Step(1)
+}
+
+// Step returns an iterator over every n-th day in d, starting at d.Start.
+// It yields nothing if n is not positive or d has an open start.
+func (d DateRange) Step(n int) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		if n <= 0 || !d.hasStart() {
+			return
+		}
+		for date := d.Start; !d.hasEnd() || !date.After(d.End); date = date.AddDays(n) {
+			if !yield(date) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over every day in d in descending order. It
+// yields nothing if d has an open end and never stops if d has an open start.
+func (d DateRange) Backward() iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		if !d.hasEnd() {
+			return
+		}
+		for date := d.End; !d.hasStart() || !date.Before(d.Start); date = date.AddDays(-1) {
+			if !yield(date) {
+				return
+			}
+		}
+	}
+}
+
+// DaysMatching returns an iterator over the days in d whose weekday is set in
+// dow.
+func (d DateRange) DaysMatching(dow DaysOfWeek) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for date := range d.Days() {
+			if dow.Has(date.Weekday()) && !yield(date) {
+				return
+			}
+		}
+	}
+}
+
+func (d DateRange) hasStart() bool {
+	return d.StartBound == Bounded
+}
+
+func (d DateRange) hasEnd() bool {
+	return d.EndBound == Bounded
+}
+
+// compareStarts compares the lower endpoints of a and b, treating an open
+// start as smaller than any date.
+func compareStarts(a, b DateRange) int {
+	switch {
+	case !a.hasStart() && !b.hasStart():
+		return 0
+	case !a.hasStart():
+		return -1
+	case !b.hasStart():
+		return +1
+	}
+	return a.Start.Compare(b.Start)
+}
+
+// compareEnds compares the upper endpoints of a and b, treating an open end
+// as larger than any date.
+func compareEnds(a, b DateRange) int {
+	switch {
+	case !a.hasEnd() && !b.hasEnd():
+		return 0
+	case !a.hasEnd():
+		return +1
+	case !b.hasEnd():
+		return -1
+	}
+	return a.End.Compare(b.End)
+}
+
+func startsNotAfterEnd(a, b DateRange) bool {
+	return !a.hasStart() || !b.hasEnd() || !a.Start.After(b.End)
+}
+
+func endsBeforeStart(a, b DateRange) bool {
+	return a.hasEnd() && b.hasStart() && a.End.AddDays(1) == b.Start
+}
+
 type NullDateRange struct {
 	DateRange DateRange
 	Valid     bool
@@ -59,7 +534,9 @@ func (nd *NullDateRange) ScanBounds() (lowerTarget, upperTarget any) {
 }
 
 func (nd *NullDateRange) SetBoundTypes(lower, upper pgtype.BoundType) error {
-	nd.DateRange.SetBoundTypes(lower, upper)
+	if err := nd.DateRange.SetBoundTypes(lower, upper); err != nil {
+		return err
+	}
 	nd.Valid = true
 	return nil
 }
@@ -78,3 +555,47 @@ func (nd NullDateRange) Bounds() (lower, upper any) {
 
 var _ pgtype.RangeScanner = &NullDateRange{}
 var _ pgtype.RangeValuer = NullDateRange{}
+
+func (nd NullDateRange) MarshalText() ([]byte, error) {
+	if !nd.Valid {
+		return []byte{}, nil
+	}
+	return nd.DateRange.MarshalText()
+}
+
+func (nd *NullDateRange) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*nd = NullDateRange{}
+		return nil
+	}
+	if err := nd.DateRange.UnmarshalText(data); err != nil {
+		return err
+	}
+	nd.Valid = true
+	return nil
+}
+
+var _ encoding.TextMarshaler = NullDateRange{}
+var _ encoding.TextUnmarshaler = &NullDateRange{}
+
+func (nd NullDateRange) MarshalJSON() ([]byte, error) {
+	if !nd.Valid {
+		return []byte("null"), nil
+	}
+	return nd.DateRange.MarshalJSON()
+}
+
+func (nd *NullDateRange) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*nd = NullDateRange{}
+		return nil
+	}
+	if err := nd.DateRange.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	nd.Valid = true
+	return nil
+}
+
+var _ json.Marshaler = NullDateRange{}
+var _ json.Unmarshaler = &NullDateRange{}
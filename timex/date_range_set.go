@@ -0,0 +1,161 @@
+package timex
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DateRangeSet is a set of days stored as sorted, disjoint DateRanges.
+// Overlapping and adjacent ranges are merged on insertion, so two sets
+// containing the same days always hold the same ranges.
+type DateRangeSet struct {
+	ranges []DateRange
+}
+
+func NewDateRangeSet(ranges ...DateRange) DateRangeSet {
+	var s DateRangeSet
+	for _, r := range ranges {
+		s.Add(r)
+	}
+	return s
+}
+
+// Ranges returns a copy of the normalized ranges in ascending order.
+func (s DateRangeSet) Ranges() []DateRange {
+	return slices.Clone(s.ranges)
+}
+
+func (s DateRangeSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+func (s *DateRangeSet) Add(r DateRange) {
+	if r.IsEmpty() {
+		return
+	}
+
+	i, _ := slices.BinarySearchFunc(s.ranges, r, compareStarts)
+	// The range preceding the insertion point may overlap or touch r.
+	if i > 0 {
+		if u, ok := s.ranges[i-1].Union(r); ok {
+			i--
+			r = u
+		}
+	}
+
+	j := i
+	for ; j < len(s.ranges); j++ {
+		u, ok := s.ranges[j].Union(r)
+		if !ok {
+			break
+		}
+		r = u
+	}
+
+	s.ranges = slices.Replace(s.ranges, i, j, r)
+}
+
+func (s *DateRangeSet) Remove(r DateRange) {
+	if r.IsEmpty() {
+		return
+	}
+
+	result := make([]DateRange, 0, len(s.ranges)+1)
+	for _, e := range s.ranges {
+		result = append(result, e.Subtract(r)...)
+	}
+	s.ranges = result
+}
+
+func (s DateRangeSet) Contains(d Date) bool {
+	i, found := slices.BinarySearchFunc(s.ranges, DateRange{Start: d}, compareStarts)
+	if found {
+		return true
+	}
+	return i > 0 && s.ranges[i-1].Contains(d)
+}
+
+func (s DateRangeSet) Union(s2 DateRangeSet) DateRangeSet {
+	u := DateRangeSet{ranges: slices.Clone(s.ranges)}
+	for _, r := range s2.ranges {
+		u.Add(r)
+	}
+	return u
+}
+
+func (s DateRangeSet) Intersect(s2 DateRangeSet) DateRangeSet {
+	var result DateRangeSet
+	for i, j := 0, 0; i < len(s.ranges) && j < len(s2.ranges); {
+		a, b := s.ranges[i], s2.ranges[j]
+		if r, ok := a.Intersect(b); ok {
+			result.ranges = append(result.ranges, r)
+		}
+		if compareEnds(a, b) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// Complement returns the days within bounds that are not contained in s.
+func (s DateRangeSet) Complement(bounds DateRange) DateRangeSet {
+	result := NewDateRangeSet(bounds)
+	for _, r := range s.ranges {
+		result.Remove(r)
+	}
+	return result
+}
+
+// Days returns an iterator over every day contained in s in ascending order.
+func (s DateRangeSet) Days() iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for _, r := range s.ranges {
+			for d := range r.Days() {
+				if !yield(d) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *DateRangeSet) ScanNull() error {
+	return fmt.Errorf("cannot scan NULL into datemultirange")
+}
+
+func (s *DateRangeSet) SetLen(n int) error {
+	s.ranges = make([]DateRange, n)
+	return nil
+}
+
+func (s *DateRangeSet) ScanIndex(i int) any {
+	return &s.ranges[i]
+}
+
+func (s *DateRangeSet) ScanIndexType() any {
+	return new(DateRange)
+}
+
+func (s DateRangeSet) IsNull() bool {
+	return false
+}
+
+func (s DateRangeSet) Len() int {
+	return len(s.ranges)
+}
+
+func (s DateRangeSet) Index(i int) any {
+	return s.ranges[i]
+}
+
+func (s DateRangeSet) IndexType() any {
+	return DateRange{}
+}
+
+var _ pgtype.MultirangeSetter = &DateRangeSet{}
+var _ pgtype.MultirangeGetter = DateRangeSet{}
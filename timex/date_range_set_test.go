@@ -0,0 +1,220 @@
+package timex
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDateRangeSet(t *testing.T) {
+	tests := []struct {
+		desc   string
+		ranges []DateRange
+		want   []DateRange
+	}{
+		{
+			desc: "empty",
+			want: nil,
+		},
+		{
+			desc: "sorted",
+			ranges: []DateRange{
+				{Start: Date{2025, 7, 10}, End: Date{2025, 7, 12}},
+				{Start: Date{2025, 7, 1}, End: Date{2025, 7, 3}},
+			},
+			want: []DateRange{
+				{Start: Date{2025, 7, 1}, End: Date{2025, 7, 3}},
+				{Start: Date{2025, 7, 10}, End: Date{2025, 7, 12}},
+			},
+		},
+		{
+			desc: "overlapping merged",
+			ranges: []DateRange{
+				{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}},
+				{Start: Date{2025, 7, 3}, End: Date{2025, 7, 8}},
+			},
+			want: []DateRange{
+				{Start: Date{2025, 7, 1}, End: Date{2025, 7, 8}},
+			},
+		},
+		{
+			desc: "adjacent merged",
+			ranges: []DateRange{
+				{Start: Date{2025, 7, 6}, End: Date{2025, 7, 8}},
+				{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}},
+			},
+			want: []DateRange{
+				{Start: Date{2025, 7, 1}, End: Date{2025, 7, 8}},
+			},
+		},
+		{
+			desc: "bridging several ranges",
+			ranges: []DateRange{
+				{Start: Date{2025, 7, 1}, End: Date{2025, 7, 2}},
+				{Start: Date{2025, 7, 5}, End: Date{2025, 7, 6}},
+				{Start: Date{2025, 7, 9}, End: Date{2025, 7, 10}},
+				{Start: Date{2025, 7, 20}, End: Date{2025, 7, 21}},
+				{Start: Date{2025, 7, 3}, End: Date{2025, 7, 8}},
+			},
+			want: []DateRange{
+				{Start: Date{2025, 7, 1}, End: Date{2025, 7, 10}},
+				{Start: Date{2025, 7, 20}, End: Date{2025, 7, 21}},
+			},
+		},
+		{
+			desc: "invalid range ignored",
+			ranges: []DateRange{
+				{Start: Date{2025, 7, 5}, End: Date{2025, 7, 1}},
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NewDateRangeSet(tt.ranges...).Ranges(), tt.desc)
+	}
+}
+
+func TestDateRangeSetRemove(t *testing.T) {
+	s := NewDateRangeSet(
+		DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 10}},
+		DateRange{Start: Date{2025, 7, 20}, End: Date{2025, 7, 31}},
+	)
+	s.Remove(DateRange{Start: Date{2025, 7, 5}, End: Date{2025, 7, 25}})
+
+	assert.Equal(t, []DateRange{
+		{Start: Date{2025, 7, 1}, End: Date{2025, 7, 4}},
+		{Start: Date{2025, 7, 26}, End: Date{2025, 7, 31}},
+	}, s.Ranges())
+
+	s.Remove(DateRange{Start: Date{2025, 7, 2}, End: Date{2025, 7, 2}})
+	assert.Equal(t, []DateRange{
+		{Start: Date{2025, 7, 1}, End: Date{2025, 7, 1}},
+		{Start: Date{2025, 7, 3}, End: Date{2025, 7, 4}},
+		{Start: Date{2025, 7, 26}, End: Date{2025, 7, 31}},
+	}, s.Ranges())
+}
+
+func TestDateRangeSetContains(t *testing.T) {
+	s := NewDateRangeSet(
+		DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 3}},
+		DateRange{Start: Date{2025, 7, 10}, End: Date{2025, 7, 10}},
+	)
+
+	tests := []struct {
+		date Date
+		want bool
+	}{
+		{Date{2025, 6, 30}, false},
+		{Date{2025, 7, 1}, true},
+		{Date{2025, 7, 3}, true},
+		{Date{2025, 7, 4}, false},
+		{Date{2025, 7, 10}, true},
+		{Date{2025, 7, 11}, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, s.Contains(tt.date), tt.date.String())
+	}
+	assert.False(t, DateRangeSet{}.Contains(Date{2025, 7, 1}))
+}
+
+func TestDateRangeSetUnionAndIntersect(t *testing.T) {
+	a := NewDateRangeSet(
+		DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 10}},
+		DateRange{Start: Date{2025, 7, 20}, End: Date{2025, 7, 31}},
+	)
+	b := NewDateRangeSet(
+		DateRange{Start: Date{2025, 7, 5}, End: Date{2025, 7, 8}},
+		DateRange{Start: Date{2025, 7, 11}, End: Date{2025, 7, 12}},
+		DateRange{Start: Date{2025, 7, 25}, End: Date{2025, 8, 5}},
+	)
+
+	assert.Equal(t, []DateRange{
+		{Start: Date{2025, 7, 1}, End: Date{2025, 7, 12}},
+		{Start: Date{2025, 7, 20}, End: Date{2025, 8, 5}},
+	}, a.Union(b).Ranges())
+
+	assert.Equal(t, []DateRange{
+		{Start: Date{2025, 7, 5}, End: Date{2025, 7, 8}},
+		{Start: Date{2025, 7, 25}, End: Date{2025, 7, 31}},
+	}, a.Intersect(b).Ranges())
+
+	assert.True(t, a.Intersect(DateRangeSet{}).IsEmpty())
+}
+
+func TestDateRangeSetComplement(t *testing.T) {
+	s := NewDateRangeSet(
+		DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 10}},
+		DateRange{Start: Date{2025, 7, 20}, End: Date{2025, 7, 31}},
+	)
+
+	assert.Equal(t, []DateRange{
+		{Start: Date{2025, 6, 1}, End: Date{2025, 6, 30}},
+		{Start: Date{2025, 7, 11}, End: Date{2025, 7, 19}},
+		{Start: Date{2025, 8, 1}, End: Date{2025, 8, 31}},
+	}, s.Complement(DateRange{Start: Date{2025, 6, 1}, End: Date{2025, 8, 31}}).Ranges())
+
+	assert.True(t, s.Complement(DateRange{Start: Date{2025, 7, 2}, End: Date{2025, 7, 5}}).IsEmpty())
+}
+
+func TestDateRangeSetDays(t *testing.T) {
+	s := NewDateRangeSet(
+		DateRange{Start: Date{2025, 7, 30}, End: Date{2025, 8, 1}},
+		DateRange{Start: Date{2025, 8, 5}, End: Date{2025, 8, 5}},
+	)
+
+	assert.Equal(t, []Date{
+		{2025, 7, 30},
+		{2025, 7, 31},
+		{2025, 8, 1},
+		{2025, 8, 5},
+	}, slices.Collect(s.Days()))
+}
+
+func TestDateRangeSetOpenBounds(t *testing.T) {
+	s := NewDateRangeSet(
+		DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 10}},
+		DateRange{Start: Date{2025, 8, 1}, EndBound: Unbounded},
+		DateRange{StartBound: Unbounded, End: Date{2025, 1, 31}},
+	)
+
+	assert.Equal(t, []DateRange{
+		{StartBound: Unbounded, End: Date{2025, 1, 31}},
+		{Start: Date{2025, 7, 1}, End: Date{2025, 7, 10}},
+		{Start: Date{2025, 8, 1}, EndBound: Unbounded},
+	}, s.Ranges())
+	assert.True(t, s.Contains(Date{1900, 1, 1}))
+	assert.True(t, s.Contains(Date{3000, 1, 1}))
+	assert.False(t, s.Contains(Date{2025, 7, 31}))
+
+	s.Add(DateRange{Start: Date{2025, 7, 11}, End: Date{2025, 7, 31}})
+	assert.Equal(t, []DateRange{
+		{StartBound: Unbounded, End: Date{2025, 1, 31}},
+		{Start: Date{2025, 7, 1}, EndBound: Unbounded},
+	}, s.Ranges())
+
+	assert.Equal(t, []DateRange{
+		{Start: Date{2025, 2, 1}, End: Date{2025, 6, 30}},
+	}, s.Complement(DateRange{StartBound: Infinite, EndBound: Infinite}).Ranges())
+}
+
+func TestDateRangeSetMultirangeRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+	original := NewDateRangeSet(
+		DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 10}},
+		DateRange{Start: Date{2025, 12, 20}, End: Date{2026, 1, 6}},
+	)
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(pgtype.DatemultirangeOID, format, original, nil)
+		assert.NoError(t, err)
+
+		var decoded DateRangeSet
+		err = m.Scan(pgtype.DatemultirangeOID, format, buf, &decoded)
+		assert.NoError(t, err)
+		assert.Equal(t, original.Ranges(), decoded.Ranges())
+	}
+}
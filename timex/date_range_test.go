@@ -0,0 +1,649 @@
+package timex
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateRangeContains(t *testing.T) {
+	r := DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 31}}
+
+	tests := []struct {
+		date Date
+		want bool
+	}{
+		{Date{2025, 6, 30}, false},
+		{Date{2025, 7, 1}, true},
+		{Date{2025, 7, 15}, true},
+		{Date{2025, 7, 31}, true},
+		{Date{2025, 8, 1}, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, r.Contains(tt.date), tt.date.String())
+	}
+
+	single := DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 1}}
+	assert.True(t, single.Contains(Date{2025, 7, 1}))
+	assert.False(t, single.Contains(Date{2025, 7, 2}))
+}
+
+func TestDateRangeContainsRange(t *testing.T) {
+	r := DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 31}}
+
+	tests := []struct {
+		desc string
+		r2   DateRange
+		want bool
+	}{
+		{"equal", r, true},
+		{"inside", DateRange{Start: Date{2025, 7, 10}, End: Date{2025, 7, 20}}, true},
+		{"single day at start", DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 1}}, true},
+		{"single day at end", DateRange{Start: Date{2025, 7, 31}, End: Date{2025, 7, 31}}, true},
+		{"starts before", DateRange{Start: Date{2025, 6, 30}, End: Date{2025, 7, 20}}, false},
+		{"ends after", DateRange{Start: Date{2025, 7, 10}, End: Date{2025, 8, 1}}, false},
+		{"disjoint", DateRange{Start: Date{2025, 9, 1}, End: Date{2025, 9, 2}}, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, r.ContainsRange(tt.r2), tt.desc)
+	}
+}
+
+func TestDateRangeOverlapsAndAdjacent(t *testing.T) {
+	tests := []struct {
+		desc     string
+		r1, r2   DateRange
+		overlaps bool
+		adjacent bool
+	}{
+		{
+			desc:     "equal",
+			r1:       DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}},
+			r2:       DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}},
+			overlaps: true,
+		},
+		{
+			desc:     "sharing a single day",
+			r1:       DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}},
+			r2:       DateRange{Start: Date{2025, 7, 5}, End: Date{2025, 7, 9}},
+			overlaps: true,
+		},
+		{
+			desc:     "adjacent",
+			r1:       DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}},
+			r2:       DateRange{Start: Date{2025, 7, 6}, End: Date{2025, 7, 9}},
+			adjacent: true,
+		},
+		{
+			desc:     "adjacent reversed",
+			r1:       DateRange{Start: Date{2025, 7, 6}, End: Date{2025, 7, 9}},
+			r2:       DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}},
+			adjacent: true,
+		},
+		{
+			desc:     "adjacent across a year boundary",
+			r1:       DateRange{Start: Date{2024, 12, 1}, End: Date{2024, 12, 31}},
+			r2:       DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}},
+			adjacent: true,
+		},
+		{
+			desc: "one day gap",
+			r1:   DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}},
+			r2:   DateRange{Start: Date{2025, 7, 7}, End: Date{2025, 7, 9}},
+		},
+		{
+			desc:     "single days",
+			r1:       DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 1}},
+			r2:       DateRange{Start: Date{2025, 7, 2}, End: Date{2025, 7, 2}},
+			adjacent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.overlaps, tt.r1.Overlaps(tt.r2), tt.desc)
+		assert.Equal(t, tt.overlaps, tt.r2.Overlaps(tt.r1), tt.desc)
+		assert.Equal(t, tt.adjacent, tt.r1.Adjacent(tt.r2), tt.desc)
+		assert.Equal(t, tt.adjacent, tt.r2.Adjacent(tt.r1), tt.desc)
+	}
+}
+
+func TestDateRangeIntersect(t *testing.T) {
+	tests := []struct {
+		desc   string
+		r1, r2 DateRange
+		want   DateRange
+		ok     bool
+	}{
+		{
+			desc: "partial overlap",
+			r1:   DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 10}},
+			r2:   DateRange{Start: Date{2025, 7, 5}, End: Date{2025, 7, 20}},
+			want: DateRange{Start: Date{2025, 7, 5}, End: Date{2025, 7, 10}},
+			ok:   true,
+		},
+		{
+			desc: "contained",
+			r1:   DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 31}},
+			r2:   DateRange{Start: Date{2025, 7, 5}, End: Date{2025, 7, 6}},
+			want: DateRange{Start: Date{2025, 7, 5}, End: Date{2025, 7, 6}},
+			ok:   true,
+		},
+		{
+			desc: "sharing a single day",
+			r1:   DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}},
+			r2:   DateRange{Start: Date{2025, 7, 5}, End: Date{2025, 7, 9}},
+			want: DateRange{Start: Date{2025, 7, 5}, End: Date{2025, 7, 5}},
+			ok:   true,
+		},
+		{
+			desc: "adjacent",
+			r1:   DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}},
+			r2:   DateRange{Start: Date{2025, 7, 6}, End: Date{2025, 7, 9}},
+		},
+	}
+
+	for _, tt := range tests {
+		got, ok := tt.r1.Intersect(tt.r2)
+		assert.Equal(t, tt.ok, ok, tt.desc)
+		assert.Equal(t, tt.want, got, tt.desc)
+
+		got, ok = tt.r2.Intersect(tt.r1)
+		assert.Equal(t, tt.ok, ok, tt.desc)
+		assert.Equal(t, tt.want, got, tt.desc)
+	}
+}
+
+func TestDateRangeUnion(t *testing.T) {
+	tests := []struct {
+		desc   string
+		r1, r2 DateRange
+		want   DateRange
+		ok     bool
+	}{
+		{
+			desc: "partial overlap",
+			r1:   DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 10}},
+			r2:   DateRange{Start: Date{2025, 7, 5}, End: Date{2025, 7, 20}},
+			want: DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 20}},
+			ok:   true,
+		},
+		{
+			desc: "contained",
+			r1:   DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 31}},
+			r2:   DateRange{Start: Date{2025, 7, 5}, End: Date{2025, 7, 6}},
+			want: DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 31}},
+			ok:   true,
+		},
+		{
+			desc: "adjacent",
+			r1:   DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}},
+			r2:   DateRange{Start: Date{2025, 7, 6}, End: Date{2025, 7, 9}},
+			want: DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 9}},
+			ok:   true,
+		},
+		{
+			desc: "one day gap",
+			r1:   DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}},
+			r2:   DateRange{Start: Date{2025, 7, 7}, End: Date{2025, 7, 9}},
+		},
+	}
+
+	for _, tt := range tests {
+		got, ok := tt.r1.Union(tt.r2)
+		assert.Equal(t, tt.ok, ok, tt.desc)
+		assert.Equal(t, tt.want, got, tt.desc)
+
+		got, ok = tt.r2.Union(tt.r1)
+		assert.Equal(t, tt.ok, ok, tt.desc)
+		assert.Equal(t, tt.want, got, tt.desc)
+	}
+}
+
+func TestDateRangeSubtract(t *testing.T) {
+	r := DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 31}}
+
+	tests := []struct {
+		desc string
+		r2   DateRange
+		want []DateRange
+	}{
+		{
+			desc: "disjoint",
+			r2:   DateRange{Start: Date{2025, 8, 1}, End: Date{2025, 8, 5}},
+			want: []DateRange{r},
+		},
+		{
+			desc: "covering",
+			r2:   DateRange{Start: Date{2025, 6, 1}, End: Date{2025, 8, 31}},
+			want: nil,
+		},
+		{
+			desc: "equal",
+			r2:   r,
+			want: nil,
+		},
+		{
+			desc: "strictly inside",
+			r2:   DateRange{Start: Date{2025, 7, 10}, End: Date{2025, 7, 20}},
+			want: []DateRange{
+				{Start: Date{2025, 7, 1}, End: Date{2025, 7, 9}},
+				{Start: Date{2025, 7, 21}, End: Date{2025, 7, 31}},
+			},
+		},
+		{
+			desc: "single day inside",
+			r2:   DateRange{Start: Date{2025, 7, 15}, End: Date{2025, 7, 15}},
+			want: []DateRange{
+				{Start: Date{2025, 7, 1}, End: Date{2025, 7, 14}},
+				{Start: Date{2025, 7, 16}, End: Date{2025, 7, 31}},
+			},
+		},
+		{
+			desc: "overlapping start",
+			r2:   DateRange{Start: Date{2025, 6, 1}, End: Date{2025, 7, 1}},
+			want: []DateRange{{Start: Date{2025, 7, 2}, End: Date{2025, 7, 31}}},
+		},
+		{
+			desc: "overlapping end",
+			r2:   DateRange{Start: Date{2025, 7, 31}, End: Date{2025, 8, 10}},
+			want: []DateRange{{Start: Date{2025, 7, 1}, End: Date{2025, 7, 30}}},
+		},
+		{
+			desc: "empty",
+			r2:   DateRange{Start: Date{2025, 7, 10}, End: Date{2025, 7, 5}},
+			want: []DateRange{r},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, r.Subtract(tt.r2), tt.desc)
+	}
+}
+
+func TestDateRangeDays(t *testing.T) {
+	r := DateRange{Start: Date{2024, 12, 30}, End: Date{2025, 1, 2}}
+
+	assert.Equal(t, []Date{
+		{2024, 12, 30},
+		{2024, 12, 31},
+		{2025, 1, 1},
+		{2025, 1, 2},
+	}, slices.Collect(r.Days()))
+
+	assert.Equal(t, []Date{
+		{2025, 1, 2},
+		{2025, 1, 1},
+		{2024, 12, 31},
+		{2024, 12, 30},
+	}, slices.Collect(r.Backward()))
+
+	single := DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 1}}
+	assert.Equal(t, []Date{{2025, 7, 1}}, slices.Collect(single.Days()))
+	assert.Equal(t, []Date{{2025, 7, 1}}, slices.Collect(single.Backward()))
+
+	invalid := DateRange{Start: Date{2025, 7, 2}, End: Date{2025, 7, 1}}
+	assert.Empty(t, slices.Collect(invalid.Days()))
+	assert.Empty(t, slices.Collect(invalid.Backward()))
+}
+
+func TestDateRangeStep(t *testing.T) {
+	r := DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 10}}
+
+	assert.Equal(t, []Date{
+		{2025, 7, 1},
+		{2025, 7, 4},
+		{2025, 7, 7},
+		{2025, 7, 10},
+	}, slices.Collect(r.Step(3)))
+	assert.Empty(t, slices.Collect(r.Step(0)))
+	assert.Empty(t, slices.Collect(r.Step(-1)))
+}
+
+func TestDateRangeDaysMatching(t *testing.T) {
+	// 2025-07-01 is a Tuesday.
+	r := DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 14}}
+
+	assert.Equal(t, []Date{
+		{2025, 7, 5},
+		{2025, 7, 6},
+		{2025, 7, 12},
+		{2025, 7, 13},
+	}, slices.Collect(r.DaysMatching(DaysOfWeek{Sa: true, Su: true})))
+	assert.Empty(t, slices.Collect(r.DaysMatching(DaysOfWeek{})))
+}
+
+func TestDateRangeOpenBounds(t *testing.T) {
+	from := DateRange{Start: Date{2025, 1, 1}, EndBound: Unbounded}
+	until := DateRange{StartBound: Infinite, End: Date{2025, 1, 31}}
+	all := DateRange{StartBound: Unbounded, EndBound: Infinite}
+	july := DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 31}}
+
+	assert.True(t, from.Contains(Date{9999, 12, 31}))
+	assert.False(t, from.Contains(Date{2024, 12, 31}))
+	assert.True(t, until.Contains(Date{1, 1, 1}))
+	assert.False(t, until.Contains(Date{2025, 2, 1}))
+	assert.True(t, all.Contains(Date{2025, 7, 1}))
+
+	assert.True(t, from.ContainsRange(july))
+	assert.False(t, july.ContainsRange(from))
+	assert.True(t, all.ContainsRange(from))
+	assert.True(t, all.ContainsRange(until))
+	assert.False(t, from.ContainsRange(all))
+
+	assert.True(t, from.Overlaps(until))
+	assert.False(t, until.Overlaps(july))
+	assert.True(t, DateRange{StartBound: Unbounded, End: Date{2024, 12, 31}}.Adjacent(from))
+	assert.False(t, from.Adjacent(until))
+
+	got, ok := from.Intersect(until)
+	assert.True(t, ok)
+	assert.Equal(t, DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}, got)
+
+	got, ok = from.Union(until)
+	assert.True(t, ok)
+	assert.Equal(t, DateRange{StartBound: Infinite, EndBound: Unbounded}, got)
+
+	assert.Equal(t, []DateRange{
+		{Start: Date{2025, 1, 1}, End: Date{2025, 6, 30}},
+		{Start: Date{2025, 8, 1}, EndBound: Unbounded},
+	}, from.Subtract(july))
+	assert.Equal(t, []DateRange{
+		{StartBound: Unbounded, End: Date{2024, 12, 31}},
+		{Start: Date{2025, 2, 1}, EndBound: Infinite},
+	}, all.Subtract(DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}))
+	assert.Empty(t, july.Subtract(all))
+
+	assert.False(t, from.IsEmpty())
+	assert.True(t, DateRange{Start: Date{2025, 1, 2}, End: Date{2025, 1, 1}}.IsEmpty())
+}
+
+func TestDateRangeOpenBoundsIteration(t *testing.T) {
+	from := DateRange{Start: Date{2025, 12, 30}, EndBound: Unbounded}
+	var days []Date
+	for d := range from.Days() {
+		days = append(days, d)
+		if len(days) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []Date{{2025, 12, 30}, {2025, 12, 31}, {2026, 1, 1}}, days)
+	assert.Empty(t, slices.Collect(from.Backward()))
+
+	until := DateRange{StartBound: Infinite, End: Date{2025, 1, 1}}
+	assert.Empty(t, slices.Collect(until.Days()))
+	for d := range until.Backward() {
+		assert.Equal(t, Date{2025, 1, 1}, d)
+		break
+	}
+}
+
+func TestDateRangeString(t *testing.T) {
+	tests := []struct {
+		r    DateRange
+		want string
+	}{
+		{DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}, "[2025-01-01,2025-01-31]"},
+		{DateRange{Start: Date{2025, 1, 1}, EndBound: Unbounded}, "[2025-01-01,)"},
+		{DateRange{StartBound: Unbounded, End: Date{2025, 1, 31}}, "(,2025-01-31]"},
+		{DateRange{StartBound: Infinite, EndBound: Infinite}, "[-infinity,infinity]"},
+		{DateRange{Start: Date{2025, 1, 5}, End: Date{2025, 1, 1}}, "empty"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.r.String())
+	}
+}
+
+func TestDateRangeRangeRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+	tests := []DateRange{
+		{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}},
+		{Start: Date{2025, 1, 1}, End: Date{2025, 1, 1}},
+		{Start: Date{2025, 1, 1}, EndBound: Unbounded},
+		{StartBound: Unbounded, End: Date{2025, 1, 31}},
+		{StartBound: Unbounded, EndBound: Unbounded},
+		{StartBound: Infinite, End: Date{2025, 1, 31}},
+		{Start: Date{2025, 1, 1}, EndBound: Infinite},
+	}
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		for _, original := range tests {
+			buf, err := m.Encode(pgtype.DaterangeOID, format, original, nil)
+			assert.NoError(t, err)
+
+			// Reuse a populated target to make sure no state leaks between scans.
+			decoded := DateRange{Start: Date{2000, 1, 1}, End: Date{2000, 1, 2}, StartBound: Infinite}
+			err = m.Scan(pgtype.DaterangeOID, format, buf, &decoded)
+			assert.NoError(t, err)
+			assert.Equal(t, original, decoded, original.String())
+		}
+	}
+}
+
+func TestDateRangeScanExclusiveBounds(t *testing.T) {
+	m := pgtype.NewMap()
+
+	var r DateRange
+	err := m.Scan(pgtype.DaterangeOID, pgtype.TextFormatCode, []byte("[2025-01-01,2025-02-01)"), &r)
+	assert.NoError(t, err)
+	assert.Equal(t, DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}, r)
+
+	err = m.Scan(pgtype.DaterangeOID, pgtype.TextFormatCode, []byte("(-infinity,2025-02-01)"), &r)
+	assert.NoError(t, err)
+	assert.Equal(t, DateRange{StartBound: Infinite, End: Date{2025, 1, 31}}, r)
+}
+
+func TestDateRangeEmpty(t *testing.T) {
+	m := pgtype.NewMap()
+	assert.True(t, emptyDateRange.IsEmpty())
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		// pgx does not call ScanBounds for an empty range, so a populated
+		// target must still be reset.
+		r := DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 5}}
+		buf, err := m.Encode(pgtype.DaterangeOID, format, DateRange{Start: Date{2025, 1, 5}, End: Date{2025, 1, 1}}, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, m.Scan(pgtype.DaterangeOID, format, buf, &r))
+		assert.Equal(t, emptyDateRange, r)
+
+		var nr NullDateRange
+		assert.NoError(t, m.Scan(pgtype.DaterangeOID, format, buf, &nr))
+		assert.Equal(t, NullDateRange{emptyDateRange, true}, nr)
+	}
+
+	buf, err := m.Encode(pgtype.DaterangeOID, pgtype.TextFormatCode, DateRange{Start: Date{2025, 1, 5}, End: Date{2025, 1, 1}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "empty", string(buf))
+
+	var r DateRange
+	assert.NoError(t, m.Scan(pgtype.DaterangeOID, pgtype.TextFormatCode, []byte("empty"), &r))
+	assert.True(t, r.IsEmpty())
+	assert.False(t, r.Contains(Date{}))
+}
+
+func TestNullDateRangeScan(t *testing.T) {
+	m := pgtype.NewMap()
+
+	var r NullDateRange
+	err := m.Scan(pgtype.DaterangeOID, pgtype.TextFormatCode, []byte("[2025-01-01,)"), &r)
+	assert.NoError(t, err)
+	assert.True(t, r.Valid)
+	assert.Equal(t, DateRange{Start: Date{2025, 1, 1}, EndBound: Unbounded}, r.DateRange)
+
+	err = m.Scan(pgtype.DaterangeOID, pgtype.TextFormatCode, nil, &r)
+	assert.NoError(t, err)
+	assert.False(t, r.Valid)
+}
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    DateRange
+		wantErr bool
+	}{
+		{str: "[2025-01-01,2025-01-31]", want: DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}},
+		{str: "[2025-01-01,2025-02-01)", want: DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}},
+		{str: "(2024-12-31,2025-01-31]", want: DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}},
+		{str: "[2025-01-01,)", want: DateRange{Start: Date{2025, 1, 1}, EndBound: Unbounded}},
+		{str: "(,2025-01-31]", want: DateRange{StartBound: Unbounded, End: Date{2025, 1, 31}}},
+		{str: "[-infinity,infinity]", want: DateRange{StartBound: Infinite, EndBound: Infinite}},
+		{str: "(,)", want: DateRange{StartBound: Unbounded, EndBound: Unbounded}},
+		{str: "[,]", want: DateRange{StartBound: Unbounded, EndBound: Unbounded}},
+		{str: "[,2025-01-31]", want: DateRange{StartBound: Unbounded, End: Date{2025, 1, 31}}},
+		{str: "2025-01-01/2025-01-31", want: DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}},
+		{str: "2025-01-01/..", want: DateRange{Start: Date{2025, 1, 1}, EndBound: Unbounded}},
+		{str: "../2025-01-31", want: DateRange{StartBound: Unbounded, End: Date{2025, 1, 31}}},
+		{str: "", wantErr: true},
+		{str: "[]", wantErr: true},
+		{str: "empty", want: emptyDateRange},
+		{str: "[2025-01-01;2025-01-31]", wantErr: true},
+		{str: "{2025-01-01,2025-01-31}", wantErr: true},
+		{str: "[2025-01-01,2025-13-01]", wantErr: true},
+		{str: "2025-01-01/P1M", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDateRange(tt.str)
+		if tt.wantErr {
+			assert.Error(t, err, tt.str)
+			continue
+		}
+		assert.NoError(t, err, tt.str)
+		assert.Equal(t, tt.want, got, tt.str)
+	}
+}
+
+func TestDateRangeTextRoundTrip(t *testing.T) {
+	tests := []DateRange{
+		{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}},
+		{Start: Date{2025, 1, 1}, EndBound: Unbounded},
+		{StartBound: Infinite, End: Date{2025, 1, 31}},
+	}
+
+	for _, original := range tests {
+		text, err := original.MarshalText()
+		assert.NoError(t, err)
+
+		var decoded DateRange
+		assert.NoError(t, decoded.UnmarshalText(text))
+		assert.Equal(t, original, decoded)
+	}
+}
+
+func TestDateRangeMarshalTextZero(t *testing.T) {
+	for _, r := range []DateRange{
+		{},
+		{End: Date{2025, 1, 31}},
+		{StartBound: Unbounded},
+	} {
+		_, err := r.MarshalText()
+		assert.Error(t, err, r.String())
+	}
+
+	text, err := DateRange{StartBound: Unbounded, EndBound: Unbounded}.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "(,)", string(text))
+}
+
+func TestDateRangeMarshalJSON(t *testing.T) {
+	tests := []struct {
+		r    DateRange
+		want string
+	}{
+		{DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}, `{"start":"2025-01-01","end":"2025-01-31"}`},
+		{DateRange{Start: Date{2025, 1, 1}, EndBound: Unbounded}, `{"start":"2025-01-01","end":null}`},
+		{DateRange{StartBound: Infinite, EndBound: Infinite}, `{"start":"-infinity","end":"infinity"}`},
+		{DateRange{}, `{"start":"","end":""}`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.r)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, string(got))
+
+		var decoded DateRange
+		assert.NoError(t, json.Unmarshal(got, &decoded))
+		assert.Equal(t, tt.r, decoded)
+	}
+}
+
+func TestDateRangeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    DateRange
+		wantErr bool
+	}{
+		{json: `{"start":"2025-01-01","end":"2025-01-31"}`, want: DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}},
+		{json: `{"start":"2025-01-01","end":null}`, want: DateRange{Start: Date{2025, 1, 1}, EndBound: Unbounded}},
+		{json: `{"start":null,"end":null}`, want: DateRange{StartBound: Unbounded, EndBound: Unbounded}},
+		{json: `{"start":"","end":""}`, want: DateRange{}},
+		{json: `{"start":"2025-01-01"}`, wantErr: true},
+		{json: `{"start":"2025-01-01","ned":"2025-01-31"}`, wantErr: true},
+		{json: `{}`, wantErr: true},
+		{json: `"[2025-01-01,2025-02-01)"`, want: DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}},
+		{json: `"2025-01-01/2025-01-31"`, want: DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}},
+		{json: `{"start":"2025-01-01","end":"tomorrow"}`, wantErr: true},
+		{json: `"2025-01-01"`, wantErr: true},
+		{json: `42`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got DateRange
+		err := json.Unmarshal([]byte(tt.json), &got)
+		if tt.wantErr {
+			assert.Error(t, err, tt.json)
+			continue
+		}
+		assert.NoError(t, err, tt.json)
+		assert.Equal(t, tt.want, got, tt.json)
+	}
+}
+
+func TestDateRangeUnmarshalJSONNull(t *testing.T) {
+	r := DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &r))
+	assert.Equal(t, DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}, r)
+}
+
+func TestDateRangeInStruct(t *testing.T) {
+	type request struct {
+		Period DateRange     `json:"period"`
+		Stay   NullDateRange `json:"stay"`
+	}
+
+	got, err := json.Marshal(request{Period: DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"period":{"start":"2025-01-01","end":"2025-01-31"},"stay":null}`, string(got))
+
+	var decoded request
+	err = json.Unmarshal([]byte(`{"period":"2025-01-01/2025-01-31","stay":{"start":"2025-07-01","end":"2025-07-05"}}`), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}}, decoded.Period)
+	assert.True(t, decoded.Stay.Valid)
+	assert.Equal(t, DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 5}}, decoded.Stay.DateRange)
+}
+
+func TestNullDateRangeEncoding(t *testing.T) {
+	var nd NullDateRange
+
+	text, err := nd.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "", string(text))
+
+	assert.NoError(t, nd.UnmarshalText([]byte("[2025-01-01,2025-01-31]")))
+	assert.True(t, nd.Valid)
+
+	assert.NoError(t, nd.UnmarshalText(nil))
+	assert.False(t, nd.Valid)
+
+	assert.NoError(t, json.Unmarshal([]byte(`"2025-01-01/2025-01-31"`), &nd))
+	assert.True(t, nd.Valid)
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &nd))
+	assert.False(t, nd.Valid)
+}
@@ -198,3 +198,111 @@ func TestMarshalXMLPtrAttr(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, `<Foo></Foo>`, string(got))
 }
+
+func TestDateAddMonths(t *testing.T) {
+	tests := []struct {
+		date     Date
+		months   int
+		clamped  Date
+		overflow Date
+	}{
+		{Date{2025, 1, 15}, 1, Date{2025, 2, 15}, Date{2025, 2, 15}},
+		{Date{2025, 1, 31}, 1, Date{2025, 2, 28}, Date{2025, 3, 3}},
+		{Date{2024, 1, 31}, 1, Date{2024, 2, 29}, Date{2024, 3, 2}},
+		{Date{2025, 3, 31}, 1, Date{2025, 4, 30}, Date{2025, 5, 1}},
+		{Date{2025, 3, 31}, -1, Date{2025, 2, 28}, Date{2025, 3, 3}},
+		{Date{2025, 11, 30}, 3, Date{2026, 2, 28}, Date{2026, 3, 2}},
+		{Date{2025, 1, 31}, -13, Date{2023, 12, 31}, Date{2023, 12, 31}},
+		{Date{2025, 5, 31}, 0, Date{2025, 5, 31}, Date{2025, 5, 31}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.clamped, tt.date.AddMonths(tt.months), "%s %+d", tt.date, tt.months)
+		assert.Equal(t, tt.overflow, tt.date.AddMonthsOverflow(tt.months), "%s %+d", tt.date, tt.months)
+	}
+}
+
+func TestDateAddYears(t *testing.T) {
+	tests := []struct {
+		date     Date
+		years    int
+		clamped  Date
+		overflow Date
+	}{
+		{Date{2024, 2, 29}, 1, Date{2025, 2, 28}, Date{2025, 3, 1}},
+		{Date{2024, 2, 29}, 4, Date{2028, 2, 29}, Date{2028, 2, 29}},
+		{Date{2024, 2, 29}, -1, Date{2023, 2, 28}, Date{2023, 3, 1}},
+		{Date{2025, 7, 1}, 10, Date{2035, 7, 1}, Date{2035, 7, 1}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.clamped, tt.date.AddYears(tt.years), "%s %+d", tt.date, tt.years)
+		assert.Equal(t, tt.overflow, tt.date.AddYearsOverflow(tt.years), "%s %+d", tt.date, tt.years)
+	}
+}
+
+func TestMonthsAndYearsBetween(t *testing.T) {
+	tests := []struct {
+		desc       string
+		start, end Date
+		months     int
+		years      int
+	}{
+		{"same day", Date{2025, 1, 15}, Date{2025, 1, 15}, 0, 0},
+		{"one day short of a month", Date{2025, 1, 15}, Date{2025, 2, 14}, 0, 0},
+		{"exactly one month", Date{2025, 1, 15}, Date{2025, 2, 15}, 1, 0},
+		{"month end clamped", Date{2025, 1, 31}, Date{2025, 2, 28}, 1, 0},
+		{"one day short of a year", Date{2000, 7, 1}, Date{2025, 6, 30}, 299, 24},
+		{"birthday", Date{2000, 7, 1}, Date{2025, 7, 1}, 300, 25},
+		{"leap birthday in non-leap year", Date{2000, 2, 29}, Date{2025, 2, 28}, 300, 25},
+		{"leap birthday the day before", Date{2000, 2, 29}, Date{2025, 2, 27}, 299, 24},
+		{"negative", Date{2025, 2, 15}, Date{2025, 1, 15}, -1, 0},
+		{"negative partial", Date{2025, 2, 15}, Date{2025, 1, 16}, 0, 0},
+		{"negative years", Date{2025, 7, 1}, Date{2023, 6, 30}, -24, -2},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.months, MonthsBetween(tt.start, tt.end), tt.desc)
+		assert.Equal(t, tt.years, YearsBetween(tt.start, tt.end), tt.desc)
+	}
+}
+
+func TestDateStartAndEndOfMonth(t *testing.T) {
+	assert.Equal(t, Date{2024, 2, 1}, Date{2024, 2, 17}.StartOfMonth())
+	assert.Equal(t, Date{2024, 2, 29}, Date{2024, 2, 17}.EndOfMonth())
+	assert.Equal(t, Date{2025, 12, 31}, Date{2025, 12, 31}.EndOfMonth())
+}
+
+func TestDateStartAndEndOfWeek(t *testing.T) {
+	// 2025-07-03 is a Thursday.
+	d := Date{2025, 7, 3}
+
+	tests := []struct {
+		first      time.Weekday
+		start, end Date
+	}{
+		{time.Monday, Date{2025, 6, 30}, Date{2025, 7, 6}},
+		{time.Sunday, Date{2025, 6, 29}, Date{2025, 7, 5}},
+		{time.Thursday, Date{2025, 7, 3}, Date{2025, 7, 9}},
+		{time.Friday, Date{2025, 6, 27}, Date{2025, 7, 3}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.start, d.StartOfWeek(tt.first), tt.first.String())
+		assert.Equal(t, tt.end, d.EndOfWeek(tt.first), tt.first.String())
+	}
+}
+
+func TestDateIsLeapYear(t *testing.T) {
+	assert.True(t, Date{2024, 1, 1}.IsLeapYear())
+	assert.True(t, Date{2000, 1, 1}.IsLeapYear())
+	assert.False(t, Date{1900, 1, 1}.IsLeapYear())
+	assert.False(t, Date{2025, 1, 1}.IsLeapYear())
+}
+
+func TestDateDayOfYear(t *testing.T) {
+	assert.Equal(t, 1, Date{2025, 1, 1}.DayOfYear())
+	assert.Equal(t, 60, Date{2025, 3, 1}.DayOfYear())
+	assert.Equal(t, 61, Date{2024, 3, 1}.DayOfYear())
+	assert.Equal(t, 366, Date{2024, 12, 31}.DayOfYear())
+}
@@ -1,8 +1,14 @@
 package timex
 
 import (
+	"bytes"
 	"cmp"
+	"encoding"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"iter"
+	"strings"
 	"time"
 
 	"github.com/HGV/x/cmpx"
@@ -13,6 +19,32 @@ type DaysOfWeek struct {
 	Mo, Tu, We, Th, Fr, Sa, Su bool
 }
 
+// EveryDay returns all seven days of the week.
+func EveryDay() DaysOfWeek {
+	return DaysOfWeek{Mo: true, Tu: true, We: true, Th: true, Fr: true, Sa: true, Su: true}
+}
+
+// Weekdays returns Monday through Friday.
+func Weekdays() DaysOfWeek {
+	return DaysOfWeek{Mo: true, Tu: true, We: true, Th: true, Fr: true}
+}
+
+// Weekend returns Saturday and Sunday.
+func Weekend() DaysOfWeek {
+	return DaysOfWeek{Sa: true, Su: true}
+}
+
+// NewDaysOfWeek returns the set of the given weekdays.
+func NewDaysOfWeek(weekdays ...time.Weekday) DaysOfWeek {
+	var w DaysOfWeek
+	for _, weekday := range weekdays {
+		if day := w.day(weekday); day != nil {
+			*day = true
+		}
+	}
+	return w
+}
+
 func ParseDaysOfWeek(s []bool) (*DaysOfWeek, error) {
 	if len(s) != 7 {
 		return nil, errors.New("invalid days of week length")
@@ -30,24 +62,97 @@ func ParseDaysOfWeek(s []bool) (*DaysOfWeek, error) {
 }
 
 func (w DaysOfWeek) Has(weekday time.Weekday) bool {
+	day := w.day(weekday)
+	return day != nil && *day
+}
+
+func (w *DaysOfWeek) day(weekday time.Weekday) *bool {
 	switch weekday {
 	case time.Monday:
-		return w.Mo
+		return &w.Mo
 	case time.Tuesday:
-		return w.Tu
+		return &w.Tu
 	case time.Wednesday:
-		return w.We
+		return &w.We
 	case time.Thursday:
-		return w.Th
+		return &w.Th
 	case time.Friday:
-		return w.Fr
+		return &w.Fr
 	case time.Saturday:
-		return w.Sa
+		return &w.Sa
 	case time.Sunday:
-		return w.Su
+		return &w.Su
 	default:
-		return false
+		return nil
+	}
+}
+
+func (w DaysOfWeek) Union(w2 DaysOfWeek) DaysOfWeek {
+	return newDaysOfWeekFromMask(w.mask() | w2.mask())
+}
+
+func (w DaysOfWeek) Intersect(w2 DaysOfWeek) DaysOfWeek {
+	return newDaysOfWeekFromMask(w.mask() & w2.mask())
+}
+
+func (w DaysOfWeek) Complement() DaysOfWeek {
+	return newDaysOfWeekFromMask(^w.mask())
+}
+
+// Count returns the number of days in w.
+func (w DaysOfWeek) Count() int {
+	var n int
+	for _, b := range w.DaysOfWeek() {
+		if b {
+			n++
+		}
+	}
+	return n
+}
+
+func (w DaysOfWeek) IsEmpty() bool {
+	return w == DaysOfWeek{}
+}
+
+func (w DaysOfWeek) IsEveryDay() bool {
+	return w == EveryDay()
+}
+
+// All returns an iterator over the days in w from Monday to Sunday.
+func (w DaysOfWeek) All() iter.Seq[time.Weekday] {
+	return w.AllFrom(time.Monday)
+}
+
+// AllFrom returns an iterator over the days in w in the order of a week
+// starting on first, e.g. time.Sunday for the US convention.
+func (w DaysOfWeek) AllFrom(first time.Weekday) iter.Seq[time.Weekday] {
+	return func(yield func(time.Weekday) bool) {
+		for i := range 7 {
+			weekday := (first + time.Weekday(i)) % 7
+			if w.Has(weekday) && !yield(weekday) {
+				return
+			}
+		}
+	}
+}
+
+// mask returns w as a bit mask with Monday as the lowest bit.
+func (w DaysOfWeek) mask() uint8 {
+	var m uint8
+	for i, b := range w.DaysOfWeek() {
+		if b {
+			m |= 1 << i
+		}
 	}
+	return m
+}
+
+func newDaysOfWeekFromMask(m uint8) DaysOfWeek {
+	var s [7]bool
+	for i := range s {
+		s[i] = m&(1<<i) != 0
+	}
+	return DaysOfWeek{Mo: s[0], Tu: s[1], We: s[2], Th: s[3], Fr: s[4], Sa: s[5], Su: s[6]}
 }
 
 func (w DaysOfWeek) DaysOfWeek() []bool {
@@ -66,13 +171,186 @@ func (w DaysOfWeek) Compare(w2 DaysOfWeek) int {
 	)
 }
 
+const compactDaysOfWeek = "MTWTFSS"
+
+var dayCodes = [7]string{"mo", "tu", "we", "th", "fr", "sa", "su"}
+
+var shortDayNames = map[string][7]string{
+	"en": {"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"},
+	"de": {"Mo", "Di", "Mi", "Do", "Fr", "Sa", "So"},
+	"it": {"Lu", "Ma", "Me", "Gi", "Ve", "Sa", "Do"},
+}
+
+// String returns w in the compact form MTWTF-- with a dash for every missing
+// day.
+func (w DaysOfWeek) String() string {
+	var sb strings.Builder
+	for i, b := range w.DaysOfWeek() {
+		if b {
+			sb.WriteByte(compactDaysOfWeek[i])
+		} else {
+			sb.WriteByte('-')
+		}
+	}
+	return sb.String()
+}
+
+// ParseDaysOfWeekString parses the compact form MTWTF-- as well as a
+// comma-separated list of day codes such as mo,tu,we.
+func ParseDaysOfWeekString(s string) (DaysOfWeek, error) {
+	if len(s) == 7 && !strings.Contains(s, ",") {
+		var m uint8
+		for i := range 7 {
+			switch s[i] {
+			case compactDaysOfWeek[i]:
+				m |= 1 << i
+			case '-':
+			default:
+				return DaysOfWeek{}, fmt.Errorf("invalid days of week %q", s)
+			}
+		}
+		return newDaysOfWeekFromMask(m), nil
+	}
+
+	var w DaysOfWeek
+	if s == "" {
+		return w, nil
+	}
+	for code := range strings.SplitSeq(s, ",") {
+		weekday, err := parseDayCode(code)
+		if err != nil {
+			return DaysOfWeek{}, err
+		}
+		*w.day(weekday) = true
+	}
+	return w, nil
+}
+
+func parseDayCode(code string) (time.Weekday, error) {
+	for i, c := range dayCodes {
+		if strings.EqualFold(code, c) {
+			return time.Weekday((i + 1) % 7), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day of week %q", code)
+}
+
+func (w DaysOfWeek) MarshalText() ([]byte, error) {
+	return []byte(w.String()), nil
+}
+
+func (w *DaysOfWeek) UnmarshalText(data []byte) error {
+	var err error
+	*w, err = ParseDaysOfWeekString(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = DaysOfWeek{}
+var _ encoding.TextUnmarshaler = &DaysOfWeek{}
+
+// MarshalJSON encodes w as an object, e.g. {"Mo":true,"Tu":false,…}. Use
+// DaysOfWeekList for a list of day codes instead.
+func (w DaysOfWeek) MarshalJSON() ([]byte, error) {
+	// Without the conversion, MarshalText would turn w into a string.
+	type object DaysOfWeek
+	return json.Marshal(object(w))
+}
+
+// UnmarshalJSON accepts the object form produced by MarshalJSON, a list of
+// day codes as produced by DaysOfWeekList and a string in any form accepted
+// by ParseDaysOfWeekString. A JSON null leaves w unchanged.
+func (w *DaysOfWeek) UnmarshalJSON(data []byte) error {
+	switch {
+	case string(data) == "null":
+		return nil
+	case bytes.HasPrefix(data, []byte("{")):
+		type object DaysOfWeek
+		var o object
+		if err := json.Unmarshal(data, &o); err != nil {
+			return err
+		}
+		*w = DaysOfWeek(o)
+		return nil
+	case bytes.HasPrefix(data, []byte(`"`)):
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return w.UnmarshalText([]byte(s))
+	}
+
+	var codes []string
+	if err := json.Unmarshal(data, &codes); err != nil {
+		return err
+	}
+	var parsed DaysOfWeek
+	for _, code := range codes {
+		weekday, err := parseDayCode(code)
+		if err != nil {
+			return err
+		}
+		*parsed.day(weekday) = true
+	}
+	*w = parsed
+	return nil
+}
+
+var _ json.Marshaler = DaysOfWeek{}
+var _ json.Unmarshaler = &DaysOfWeek{}
+
+// DaysOfWeekList is a DaysOfWeek encoded in JSON as a list of day codes, e.g.
+// ["mo","tu"].
+type DaysOfWeekList DaysOfWeek
+
+func (l DaysOfWeekList) MarshalJSON() ([]byte, error) {
+	codes := []string{}
+	for i, b := range DaysOfWeek(l).DaysOfWeek() {
+		if b {
+			codes = append(codes, dayCodes[i])
+		}
+	}
+	return json.Marshal(codes)
+}
+
+// UnmarshalJSON accepts every form accepted by DaysOfWeek.UnmarshalJSON.
+func (l *DaysOfWeekList) UnmarshalJSON(data []byte) error {
+	return (*DaysOfWeek)(l).UnmarshalJSON(data)
+}
+
+var _ json.Marshaler = DaysOfWeekList{}
+var _ json.Unmarshaler = &DaysOfWeekList{}
+
+// ShortWeekdayName returns the two-letter abbreviation of weekday in lang,
+// which is one of en, de or it. Other languages fall back to English.
+func ShortWeekdayName(weekday time.Weekday, lang string) string {
+	names, ok := shortDayNames[lang]
+	if !ok {
+		names = shortDayNames["en"]
+	}
+	return names[(weekday+6)%7]
+}
+
+// ShortNames returns the abbreviations of the days in w from Monday to Sunday
+// in lang as described for ShortWeekdayName.
+func (w DaysOfWeek) ShortNames(lang string) []string {
+	var names []string
+	for weekday := range w.All() {
+		names = append(names, ShortWeekdayName(weekday, lang))
+	}
+	return names
+}
+
 func (w *DaysOfWeek) ScanBits(bits pgtype.Bits) error {
-	bitsLen := int(bits.Len)
-	b := bits.Bytes[0]
-	s := make([]bool, 0, bitsLen)
-	for i := range bitsLen {
-		bit := b & (1 << (bitsLen - i))
-		s = append(s, bit != 0)
+	if !bits.Valid {
+		return errors.New("cannot scan NULL into DaysOfWeek")
+	}
+	if bits.Len != 7 || len(bits.Bytes) != 1 {
+		return fmt.Errorf("invalid days of week length %d", bits.Len)
+	}
+
+	s := make([]bool, 7)
+	for i := range s {
+		s[i] = bits.Bytes[0]&(0x80>>i) != 0
 	}
 
 	parsed, err := ParseDaysOfWeek(s)
@@ -86,15 +364,14 @@ func (w *DaysOfWeek) ScanBits(bits pgtype.Bits) error {
 
 func (src DaysOfWeek) BitsValue() (pgtype.Bits, error) {
 	var acc uint8
-	bitsLen := 7
 	for i, b := range src.DaysOfWeek() {
 		if b {
-			acc += 2 << (bitsLen - (i + 1))
+			acc |= 0x80 >> i
 		}
 	}
 	return pgtype.Bits{
 		Bytes: []byte{acc},
-		Len:   int32(bitsLen),
+		Len:   7,
 		Valid: true,
 	}, nil
 }
@@ -102,6 +379,137 @@ func (src DaysOfWeek) BitsValue() (pgtype.Bits, error) {
 var _ pgtype.BitsScanner = &DaysOfWeek{}
 var _ pgtype.BitsValuer = DaysOfWeek{}
 
+// ScanInt64 scans a bit mask with Monday as the lowest bit, as stored in
+// smallint columns.
+func (w *DaysOfWeek) ScanInt64(v pgtype.Int8) error {
+	if !v.Valid {
+		return errors.New("cannot scan NULL into DaysOfWeek")
+	}
+	if v.Int64 < 0 || v.Int64 > 0x7f {
+		return fmt.Errorf("invalid days of week bit mask %d", v.Int64)
+	}
+
+	*w = newDaysOfWeekFromMask(uint8(v.Int64))
+	return nil
+}
+
+func (w DaysOfWeek) Int64Value() (pgtype.Int8, error) {
+	return pgtype.Int8{Int64: int64(w.mask()), Valid: true}, nil
+}
+
+var _ pgtype.Int64Scanner = &DaysOfWeek{}
+var _ pgtype.Int64Valuer = DaysOfWeek{}
+
+// DaysOfWeekBools is a DaysOfWeek stored as boolean[] of exactly seven
+// elements from Monday to Sunday.
+type DaysOfWeekBools DaysOfWeek
+
+func (w DaysOfWeekBools) Dimensions() []pgtype.ArrayDimension {
+	return []pgtype.ArrayDimension{{Length: 7, LowerBound: 1}}
+}
+
+func (w DaysOfWeekBools) Index(i int) any {
+	return DaysOfWeek(w).DaysOfWeek()[i]
+}
+
+func (w DaysOfWeekBools) IndexType() any {
+	return false
+}
+
+func (w *DaysOfWeekBools) SetDimensions(dimensions []pgtype.ArrayDimension) error {
+	if dimensions == nil {
+		return errors.New("cannot scan NULL into DaysOfWeekBools")
+	}
+	if len(dimensions) != 1 || dimensions[0].Length != 7 {
+		return errors.New("invalid days of week length")
+	}
+	*w = DaysOfWeekBools{}
+	return nil
+}
+
+func (w *DaysOfWeekBools) ScanIndex(i int) any {
+	return (*DaysOfWeek)(w).day(time.Weekday((i + 1) % 7))
+}
+
+func (w *DaysOfWeekBools) ScanIndexType() any {
+	return new(bool)
+}
+
+var _ pgtype.ArrayGetter = DaysOfWeekBools{}
+var _ pgtype.ArraySetter = &DaysOfWeekBools{}
+
+// DaysOfWeekNames is a DaysOfWeek stored as text[] of day codes, e.g.
+// {mo,tu}. Scanning also accepts English day names such as Monday in any
+// case.
+type DaysOfWeekNames DaysOfWeek
+
+func (w DaysOfWeekNames) Dimensions() []pgtype.ArrayDimension {
+	return []pgtype.ArrayDimension{{Length: int32(DaysOfWeek(w).Count()), LowerBound: 1}}
+}
+
+func (w DaysOfWeekNames) Index(i int) any {
+	var n int
+	for weekday := range DaysOfWeek(w).All() {
+		if n == i {
+			return dayCodes[(weekday+6)%7]
+		}
+		n++
+	}
+	return nil
+}
+
+func (w DaysOfWeekNames) IndexType() any {
+	return ""
+}
+
+func (w *DaysOfWeekNames) SetDimensions(dimensions []pgtype.ArrayDimension) error {
+	if dimensions == nil {
+		return errors.New("cannot scan NULL into DaysOfWeekNames")
+	}
+	if len(dimensions) > 1 {
+		return errors.New("invalid days of week dimensions")
+	}
+	*w = DaysOfWeekNames{}
+	return nil
+}
+
+func (w *DaysOfWeekNames) ScanIndex(i int) any {
+	return &dayName{(*DaysOfWeek)(w)}
+}
+
+func (w *DaysOfWeekNames) ScanIndexType() any {
+	return &dayName{}
+}
+
+var _ pgtype.ArrayGetter = DaysOfWeekNames{}
+var _ pgtype.ArraySetter = &DaysOfWeekNames{}
+
+// dayName adds a single scanned day name to a DaysOfWeek.
+type dayName struct {
+	w *DaysOfWeek
+}
+
+func (d *dayName) ScanText(v pgtype.Text) error {
+	if !v.Valid {
+		return errors.New("cannot scan NULL into day of week")
+	}
+
+	weekday, err := parseDayCode(v.String)
+	if err != nil {
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			if strings.EqualFold(v.String, wd.String()) {
+				weekday, err = wd, nil
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	*d.w.day(weekday) = true
+	return nil
+}
+
 type NullDaysOfWeek struct {
 	DaysOfWeek DaysOfWeek
 	Valid      bool
@@ -132,3 +540,24 @@ func (nd NullDaysOfWeek) BitsValue() (pgtype.Bits, error) {
 
 var _ pgtype.BitsScanner = &NullDaysOfWeek{}
 var _ pgtype.BitsValuer = NullDaysOfWeek{}
+
+func (nd *NullDaysOfWeek) ScanInt64(v pgtype.Int8) error {
+	if !v.Valid {
+		nd.DaysOfWeek, nd.Valid = DaysOfWeek{}, false
+		return nil
+	}
+
+	err := nd.DaysOfWeek.ScanInt64(v)
+	nd.Valid = err == nil
+	return err
+}
+
+func (nd NullDaysOfWeek) Int64Value() (pgtype.Int8, error) {
+	if !nd.Valid {
+		return pgtype.Int8{}, nil
+	}
+	return nd.DaysOfWeek.Int64Value()
+}
+
+var _ pgtype.Int64Scanner = &NullDaysOfWeek{}
+var _ pgtype.Int64Valuer = NullDaysOfWeek{}
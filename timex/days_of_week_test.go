@@ -1,6 +1,8 @@
 package timex
 
 import (
+	"encoding/json"
+	"slices"
 	"testing"
 	"testing/quick"
 	"time"
@@ -125,3 +127,248 @@ func TestNullDaysOfWeek_Invalid(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, bits.Valid)
 }
+
+func TestNewDaysOfWeek(t *testing.T) {
+	assert.Equal(t, DaysOfWeek{Mo: true, Fr: true, Su: true}, NewDaysOfWeek(time.Sunday, time.Monday, time.Friday, time.Monday))
+	assert.Equal(t, DaysOfWeek{}, NewDaysOfWeek())
+	assert.Equal(t, DaysOfWeek{}, NewDaysOfWeek(time.Weekday(8)))
+}
+
+func TestDaysOfWeekSetOperations(t *testing.T) {
+	moWe := DaysOfWeek{Mo: true, We: true}
+	weFr := DaysOfWeek{We: true, Fr: true}
+
+	assert.Equal(t, DaysOfWeek{Mo: true, We: true, Fr: true}, moWe.Union(weFr))
+	assert.Equal(t, DaysOfWeek{We: true}, moWe.Intersect(weFr))
+	assert.Equal(t, DaysOfWeek{Tu: true, Th: true, Fr: true, Sa: true, Su: true}, moWe.Complement())
+	assert.Equal(t, Weekend(), Weekdays().Complement())
+	assert.Equal(t, EveryDay(), Weekdays().Union(Weekend()))
+	assert.True(t, Weekdays().Intersect(Weekend()).IsEmpty())
+
+	assert.Equal(t, 2, moWe.Count())
+	assert.Equal(t, 7, EveryDay().Count())
+	assert.Equal(t, 0, DaysOfWeek{}.Count())
+	assert.True(t, EveryDay().IsEveryDay())
+	assert.False(t, Weekdays().IsEveryDay())
+	assert.False(t, moWe.IsEmpty())
+}
+
+func TestDaysOfWeekAll(t *testing.T) {
+	dow := DaysOfWeek{Mo: true, We: true, Su: true}
+
+	assert.Equal(t, []time.Weekday{time.Monday, time.Wednesday, time.Sunday}, slices.Collect(dow.All()))
+	assert.Equal(t, []time.Weekday{time.Sunday, time.Monday, time.Wednesday}, slices.Collect(dow.AllFrom(time.Sunday)))
+	assert.Equal(t, []time.Weekday{time.Wednesday, time.Sunday, time.Monday}, slices.Collect(dow.AllFrom(time.Tuesday)))
+	assert.Empty(t, slices.Collect(DaysOfWeek{}.All()))
+}
+
+func TestDaysOfWeekString(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    DaysOfWeek
+		wantStr string
+		wantErr bool
+	}{
+		{str: "MTWTF--", want: Weekdays(), wantStr: "MTWTF--"},
+		{str: "-----SS", want: Weekend(), wantStr: "-----SS"},
+		{str: "-------", want: DaysOfWeek{}, wantStr: "-------"},
+		{str: "mo,we,su", want: DaysOfWeek{Mo: true, We: true, Su: true}, wantStr: "M-W---S"},
+		{str: "Mo,Fr", want: DaysOfWeek{Mo: true, Fr: true}, wantStr: "M---F--"},
+		{str: "", want: DaysOfWeek{}, wantStr: "-------"},
+		{str: "TMWTF--", wantErr: true},
+		{str: "MTWTF-", wantErr: true},
+		{str: "mo,xx", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseDaysOfWeekString(tt.str)
+		if tt.wantErr {
+			assert.Error(t, err, tt.str)
+			continue
+		}
+		assert.NoError(t, err, tt.str)
+		assert.Equal(t, tt.want, got, tt.str)
+		assert.Equal(t, tt.wantStr, got.String(), tt.str)
+	}
+}
+
+func TestDaysOfWeekJSON(t *testing.T) {
+	got, err := json.Marshal(DaysOfWeek{Mo: true, Tu: true, Su: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Mo":true,"Tu":true,"We":false,"Th":false,"Fr":false,"Sa":false,"Su":true}`, string(got))
+
+	got, err = json.Marshal(DaysOfWeekList{Mo: true, Tu: true, Su: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `["mo","tu","su"]`, string(got))
+
+	got, err = json.Marshal(DaysOfWeekList{})
+	assert.NoError(t, err)
+	assert.Equal(t, `[]`, string(got))
+
+	var list DaysOfWeekList
+	assert.NoError(t, json.Unmarshal([]byte(`["sa","su"]`), &list))
+	assert.Equal(t, DaysOfWeekList(Weekend()), list)
+
+	w := Weekend()
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &w))
+	assert.Equal(t, Weekend(), w)
+
+	tests := []struct {
+		json    string
+		want    DaysOfWeek
+		wantErr bool
+	}{
+		{json: `["mo","tu","su"]`, want: DaysOfWeek{Mo: true, Tu: true, Su: true}},
+		{json: `"MTWTF--"`, want: Weekdays()},
+		{json: `"sa,su"`, want: Weekend()},
+		{json: `{"Sa":true,"Su":true}`, want: Weekend()},
+		{json: `["xx"]`, wantErr: true},
+		{json: `42`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got DaysOfWeek
+		err := json.Unmarshal([]byte(tt.json), &got)
+		if tt.wantErr {
+			assert.Error(t, err, tt.json)
+			continue
+		}
+		assert.NoError(t, err, tt.json)
+		assert.Equal(t, tt.want, got, tt.json)
+	}
+}
+
+func TestShortWeekdayName(t *testing.T) {
+	tests := []struct {
+		lang string
+		want []string
+	}{
+		{"en", []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"}},
+		{"de", []string{"Mo", "Di", "Mi", "Do", "Fr", "Sa", "So"}},
+		{"it", []string{"Lu", "Ma", "Me", "Gi", "Ve", "Sa", "Do"}},
+		{"fr", []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, EveryDay().ShortNames(tt.lang), tt.lang)
+	}
+
+	assert.Equal(t, "So", ShortWeekdayName(time.Sunday, "de"))
+	assert.Equal(t, []string{"Sa", "Do"}, Weekend().ShortNames("it"))
+}
+
+func TestDaysOfWeekScanBitsInvalid(t *testing.T) {
+	tests := []pgtype.Bits{
+		{Valid: false},
+		{Valid: true},
+		{Bytes: []byte{0xfe}, Len: 8, Valid: true},
+		{Bytes: []byte{0xfe, 0}, Len: 7, Valid: true},
+	}
+
+	for _, bits := range tests {
+		var dow DaysOfWeek
+		assert.Error(t, dow.ScanBits(bits))
+	}
+}
+
+func TestDaysOfWeekRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+	values := []DaysOfWeek{
+		{},
+		Weekdays(),
+		Weekend(),
+		{Mo: true, We: true, Su: true},
+		EveryDay(),
+	}
+
+	for _, original := range values {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			buf, err := m.Encode(pgtype.BitOID, format, original, nil)
+			assert.NoError(t, err)
+			var bits DaysOfWeek
+			assert.NoError(t, m.Scan(pgtype.BitOID, format, buf, &bits))
+			assert.Equal(t, original, bits)
+
+			buf, err = m.Encode(pgtype.Int2OID, format, original, nil)
+			assert.NoError(t, err)
+			var mask DaysOfWeek
+			assert.NoError(t, m.Scan(pgtype.Int2OID, format, buf, &mask))
+			assert.Equal(t, original, mask)
+
+			buf, err = m.Encode(pgtype.BoolArrayOID, format, DaysOfWeekBools(original), nil)
+			assert.NoError(t, err)
+			var bools DaysOfWeekBools
+			assert.NoError(t, m.Scan(pgtype.BoolArrayOID, format, buf, &bools))
+			assert.Equal(t, original, DaysOfWeek(bools))
+
+			buf, err = m.Encode(pgtype.TextArrayOID, format, DaysOfWeekNames(original), nil)
+			assert.NoError(t, err)
+			var names DaysOfWeekNames
+			assert.NoError(t, m.Scan(pgtype.TextArrayOID, format, buf, &names))
+			assert.Equal(t, original, DaysOfWeek(names))
+		}
+	}
+}
+
+func TestDaysOfWeekEncodings(t *testing.T) {
+	m := pgtype.NewMap()
+
+	buf, err := m.Encode(pgtype.Int2OID, pgtype.TextFormatCode, DaysOfWeek{Mo: true, We: true, Su: true}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "69", string(buf))
+
+	buf, err = m.Encode(pgtype.BitOID, pgtype.TextFormatCode, DaysOfWeek{Mo: true, We: true, Su: true}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "1010001", string(buf))
+
+	buf, err = m.Encode(pgtype.TextArrayOID, pgtype.TextFormatCode, DaysOfWeekNames{Mo: true, Su: true}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "{mo,su}", string(buf))
+
+	var names DaysOfWeekNames
+	assert.NoError(t, m.Scan(pgtype.TextArrayOID, pgtype.TextFormatCode, []byte("{Monday,FRIDAY,su}"), &names))
+	assert.Equal(t, DaysOfWeekNames{Mo: true, Fr: true, Su: true}, names)
+}
+
+func TestDaysOfWeekScanInvalid(t *testing.T) {
+	m := pgtype.NewMap()
+
+	tests := []struct {
+		oid uint32
+		src string
+		dst any
+	}{
+		{pgtype.Int2OID, "128", &DaysOfWeek{}},
+		{pgtype.Int2OID, "-1", &DaysOfWeek{}},
+		{pgtype.BitOID, "101", &DaysOfWeek{}},
+		{pgtype.BoolArrayOID, "{t,f,t}", &DaysOfWeekBools{}},
+		{pgtype.BoolArrayOID, "{t,f,t,f,t,f,t,f}", &DaysOfWeekBools{}},
+		{pgtype.BoolArrayOID, "{t,f,t,f,t,f,NULL}", &DaysOfWeekBools{}},
+		{pgtype.TextArrayOID, "{mo,xx}", &DaysOfWeekNames{}},
+		{pgtype.TextArrayOID, "{mo,NULL}", &DaysOfWeekNames{}},
+		{pgtype.TextArrayOID, "{{mo},{tu}}", &DaysOfWeekNames{}},
+	}
+
+	for _, tt := range tests {
+		assert.Error(t, m.Scan(tt.oid, pgtype.TextFormatCode, []byte(tt.src), tt.dst), tt.src)
+	}
+
+	nulls := []struct {
+		oid uint32
+		dst any
+	}{
+		{pgtype.Int2OID, &DaysOfWeek{}},
+		{pgtype.BitOID, &DaysOfWeek{}},
+		{pgtype.BoolArrayOID, &DaysOfWeekBools{}},
+		{pgtype.TextArrayOID, &DaysOfWeekNames{}},
+	}
+	for _, tt := range nulls {
+		assert.Error(t, m.Scan(tt.oid, pgtype.TextFormatCode, nil, tt.dst), tt.oid)
+	}
+
+	var nd NullDaysOfWeek
+	assert.NoError(t, m.Scan(pgtype.Int2OID, pgtype.TextFormatCode, nil, &nd))
+	assert.False(t, nd.Valid)
+	assert.NoError(t, m.Scan(pgtype.Int2OID, pgtype.TextFormatCode, []byte("96"), &nd))
+	assert.Equal(t, NullDaysOfWeek{Weekend(), true}, nd)
+}
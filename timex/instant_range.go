@@ -0,0 +1,506 @@
+package timex
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// InstantRange is a range of instants from Start up to, but not including,
+// End, e.g. the booking of a meeting room. Either endpoint may be open, in
+// which case StartBound or EndBound is set and the corresponding time is
+// ignored. A range whose End is not after Start is empty.
+//
+// In Postgres an InstantRange maps to tstzrange. It can also be stored as
+// tsrange, which keeps the wall clock of Start and End in their locations.
+type InstantRange struct {
+	Start      time.Time
+	End        time.Time
+	StartBound Bound
+	EndBound   Bound
+}
+
+func (r *InstantRange) ScanNull() error {
+	return fmt.Errorf("cannot scan NULL into tstzrange")
+}
+
+func (r *InstantRange) ScanBounds() (lowerTarget, upperTarget any) {
+	*r = InstantRange{}
+	return &instantBound{&r.Start, &r.StartBound}, &instantBound{&r.End, &r.EndBound}
+}
+
+func (r *InstantRange) SetBoundTypes(lower, upper pgtype.BoundType) error {
+	if lower == pgtype.Empty {
+		*r = InstantRange{}
+		return nil
+	}
+
+	switch lower {
+	case pgtype.Unbounded:
+		r.StartBound = Unbounded
+	case pgtype.Exclusive:
+		if r.StartBound == Bounded {
+			r.Start = r.Start.Add(time.Microsecond)
+		}
+	}
+	switch upper {
+	case pgtype.Unbounded:
+		r.EndBound = Unbounded
+	case pgtype.Inclusive:
+		if r.EndBound == Bounded {
+			r.End = r.End.Add(time.Microsecond)
+		}
+	}
+	return nil
+}
+
+func (r InstantRange) IsNull() bool {
+	return false
+}
+
+func (r InstantRange) BoundTypes() (lower, upper pgtype.BoundType) {
+	if r.IsEmpty() {
+		return pgtype.Empty, pgtype.Empty
+	}
+
+	lower, upper = pgtype.Inclusive, pgtype.Exclusive
+	if r.StartBound == Unbounded {
+		lower = pgtype.Unbounded
+	}
+	if r.EndBound == Unbounded {
+		upper = pgtype.Unbounded
+	}
+	return lower, upper
+}
+
+func (r InstantRange) Bounds() (lower, upper any) {
+	return instantBoundValue(r.Start, r.StartBound, pgtype.NegativeInfinity),
+		instantBoundValue(r.End, r.EndBound, pgtype.Infinity)
+}
+
+var _ pgtype.RangeScanner = &InstantRange{}
+var _ pgtype.RangeValuer = InstantRange{}
+
+type instantBound struct {
+	t     *time.Time
+	bound *Bound
+}
+
+func (b *instantBound) ScanTimestamptz(v pgtype.Timestamptz) error {
+	if v.InfinityModifier != pgtype.Finite {
+		*b.t, *b.bound = time.Time{}, Infinite
+		return nil
+	}
+	*b.t, *b.bound = v.Time, Bounded
+	return nil
+}
+
+func (b *instantBound) ScanTimestamp(v pgtype.Timestamp) error {
+	return b.ScanTimestamptz(pgtype.Timestamptz{Time: v.Time, InfinityModifier: v.InfinityModifier, Valid: v.Valid})
+}
+
+type instantBoundTimestamp pgtype.Timestamptz
+
+func (v instantBoundTimestamp) TimestamptzValue() (pgtype.Timestamptz, error) {
+	return pgtype.Timestamptz(v), nil
+}
+
+func (v instantBoundTimestamp) TimestampValue() (pgtype.Timestamp, error) {
+	return pgtype.Timestamp{Time: v.Time, InfinityModifier: v.InfinityModifier, Valid: v.Valid}, nil
+}
+
+func instantBoundValue(t time.Time, b Bound, infinity pgtype.InfinityModifier) any {
+	switch b {
+	case Unbounded:
+		return nil
+	case Infinite:
+		return instantBoundTimestamp{InfinityModifier: infinity, Valid: true}
+	default:
+		return instantBoundTimestamp{Time: t, Valid: true}
+	}
+}
+
+// String returns r in the Postgres range syntax with RFC 3339 times, e.g.
+// [2025-07-01T10:00:00Z,2025-07-01T11:00:00Z), [2025-07-01T10:00:00Z,) or
+// empty.
+func (r InstantRange) String() string {
+	if r.IsEmpty() {
+		return "empty"
+	}
+
+	var b strings.Builder
+	switch r.StartBound {
+	case Unbounded:
+		b.WriteString("(")
+	case Infinite:
+		b.WriteString("[-infinity")
+	default:
+		b.WriteString("[" + r.Start.Format(time.RFC3339Nano))
+	}
+	b.WriteString(",")
+	switch r.EndBound {
+	case Unbounded:
+		b.WriteString(")")
+	case Infinite:
+		b.WriteString("infinity)")
+	default:
+		b.WriteString(r.End.Format(time.RFC3339Nano) + ")")
+	}
+	return b.String()
+}
+
+// ParseInstantRange parses a range either in the Postgres range syntax, e.g.
+// [2025-07-01T10:00:00Z,2025-07-01T11:00:00Z) or ["2025-07-01
+// 10:00:00+00","2025-07-01 11:00:00+00"], or as an ISO 8601 time interval,
+// e.g. 2025-07-01T10:00:00Z/2025-07-01T11:00:00Z. Open endpoints are written
+// as in ParseDateRange.
+func ParseInstantRange(s string) (InstantRange, error) {
+	if s == "empty" {
+		return InstantRange{}, nil
+	}
+	if !strings.HasPrefix(s, "[") && !strings.HasPrefix(s, "(") {
+		return parseISOInstantRange(s)
+	}
+	return parsePostgresInstantRange(s)
+}
+
+func parsePostgresInstantRange(s string) (InstantRange, error) {
+	if len(s) < 3 {
+		return InstantRange{}, fmt.Errorf("invalid instant range %q", s)
+	}
+	first, last := s[0], s[len(s)-1]
+	lower, upper, ok := strings.Cut(s[1:len(s)-1], ",")
+	if !ok || (last != ']' && last != ')') {
+		return InstantRange{}, fmt.Errorf("invalid instant range %q", s)
+	}
+	lower, upper = strings.Trim(lower, `"`), strings.Trim(upper, `"`)
+
+	var r InstantRange
+	var err error
+	switch lower {
+	case "":
+		r.StartBound = Unbounded
+	case "-infinity":
+		r.StartBound = Infinite
+	default:
+		if r.Start, err = parseInstant(lower); err != nil {
+			return InstantRange{}, err
+		}
+		if first == '(' {
+			r.Start = r.Start.Add(time.Microsecond)
+		}
+	}
+	switch upper {
+	case "":
+		r.EndBound = Unbounded
+	case "infinity":
+		r.EndBound = Infinite
+	default:
+		if r.End, err = parseInstant(upper); err != nil {
+			return InstantRange{}, err
+		}
+		if last == ']' {
+			r.End = r.End.Add(time.Microsecond)
+		}
+	}
+	return r, nil
+}
+
+func parseISOInstantRange(s string) (InstantRange, error) {
+	start, end, ok := strings.Cut(s, "/")
+	if !ok {
+		return InstantRange{}, fmt.Errorf("invalid instant range %q", s)
+	}
+
+	var r InstantRange
+	var err error
+	if start == ".." || start == "" {
+		r.StartBound = Unbounded
+	} else if r.Start, err = parseInstant(start); err != nil {
+		return InstantRange{}, err
+	}
+	if end == ".." || end == "" {
+		r.EndBound = Unbounded
+	} else if r.End, err = parseInstant(end); err != nil {
+		return InstantRange{}, err
+	}
+	return r, nil
+}
+
+// parseInstant parses an RFC 3339 time as well as the Postgres output format
+// of timestamptz, e.g. 2025-07-01 10:00:00+02.
+func parseInstant(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02 15:04:05Z07:00", s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02 15:04:05Z07", s)
+}
+
+func (r InstantRange) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *InstantRange) UnmarshalText(data []byte) error {
+	var err error
+	*r, err = ParseInstantRange(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = InstantRange{}
+var _ encoding.TextUnmarshaler = &InstantRange{}
+
+// instantRangeJSON is the JSON object form of an InstantRange as written by
+// MarshalJSON. An unbounded endpoint is null, an infinite one is "-infinity"
+// or "infinity".
+type instantRangeJSON struct {
+	Start *string `json:"start"`
+	End   *string `json:"end"`
+}
+
+func (r InstantRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(instantRangeJSON{
+		Start: instantBoundJSON(r.Start, r.StartBound, "-infinity"),
+		End:   instantBoundJSON(r.End, r.EndBound, "infinity"),
+	})
+}
+
+// UnmarshalJSON accepts the object form produced by MarshalJSON as well as a
+// string in any format understood by ParseInstantRange. Both keys of the
+// object form are required. A JSON null leaves r unchanged.
+func (r *InstantRange) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return r.UnmarshalText([]byte(s))
+	}
+
+	start, end, err := unmarshalRangeJSON(data)
+	if err != nil {
+		return err
+	}
+
+	var ir InstantRange
+	if ir.Start, ir.StartBound, err = parseInstantBoundJSON(start, "-infinity"); err != nil {
+		return err
+	}
+	if ir.End, ir.EndBound, err = parseInstantBoundJSON(end, "infinity"); err != nil {
+		return err
+	}
+	*r = ir
+	return nil
+}
+
+var _ json.Marshaler = InstantRange{}
+var _ json.Unmarshaler = &InstantRange{}
+
+func instantBoundJSON(t time.Time, b Bound, infinity string) *string {
+	switch b {
+	case Unbounded:
+		return nil
+	case Infinite:
+		return &infinity
+	default:
+		s := t.Format(time.RFC3339Nano)
+		return &s
+	}
+}
+
+func parseInstantBoundJSON(s *string, infinity string) (time.Time, Bound, error) {
+	switch {
+	case s == nil:
+		return time.Time{}, Unbounded, nil
+	case *s == infinity:
+		return time.Time{}, Infinite, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, *s)
+	return t, Bounded, err
+}
+
+// IsEmpty reports whether r contains no instants, i.e. both endpoints are
+// bounded and End is not after Start.
+func (r InstantRange) IsEmpty() bool {
+	return r.hasStart() && r.hasEnd() && !r.End.After(r.Start)
+}
+
+// Duration returns the length of r, or zero if r is empty. An open range has
+// no length and yields zero as well.
+func (r InstantRange) Duration() time.Duration {
+	if !r.hasStart() || !r.hasEnd() || r.IsEmpty() {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+func (r InstantRange) Contains(t time.Time) bool {
+	return (!r.hasStart() || !t.Before(r.Start)) &&
+		(!r.hasEnd() || t.Before(r.End))
+}
+
+// ContainsRange reports whether every instant of r2 is in r. Every range
+// contains the empty range.
+func (r InstantRange) ContainsRange(r2 InstantRange) bool {
+	return r2.IsEmpty() || (!r.IsEmpty() && r.compareStarts(r2) <= 0 && r.compareEnds(r2) >= 0)
+}
+
+func (r InstantRange) Overlaps(r2 InstantRange) bool {
+	return !r.IsEmpty() && !r2.IsEmpty() && r.startsBeforeEnd(r2) && r2.startsBeforeEnd(r)
+}
+
+// Adjacent reports whether one of r and r2 ends exactly where the other one
+// starts.
+func (r InstantRange) Adjacent(r2 InstantRange) bool {
+	if r.IsEmpty() || r2.IsEmpty() {
+		return false
+	}
+	return r.endsAtStart(r2) || r2.endsAtStart(r)
+}
+
+// Intersect returns the instants contained in both r and r2. The boolean
+// result is false if the ranges do not overlap.
+func (r InstantRange) Intersect(r2 InstantRange) (InstantRange, bool) {
+	if !r.Overlaps(r2) {
+		return InstantRange{}, false
+	}
+	ir := r
+	if r2.compareStarts(r) > 0 {
+		ir.Start, ir.StartBound = r2.Start, r2.StartBound
+	}
+	if r2.compareEnds(r) < 0 {
+		ir.End, ir.EndBound = r2.End, r2.EndBound
+	}
+	return ir, true
+}
+
+// Union returns the smallest range containing both r and r2. The boolean
+// result is false if the ranges neither overlap nor are adjacent, since their
+// union cannot be expressed as a single range.
+func (r InstantRange) Union(r2 InstantRange) (InstantRange, bool) {
+	switch {
+	case r2.IsEmpty():
+		return r, true
+	case r.IsEmpty():
+		return r2, true
+	case !r.Overlaps(r2) && !r.Adjacent(r2):
+		return InstantRange{}, false
+	}
+	ur := r
+	if r2.compareStarts(r) < 0 {
+		ur.Start, ur.StartBound = r2.Start, r2.StartBound
+	}
+	if r2.compareEnds(r) > 0 {
+		ur.End, ur.EndBound = r2.End, r2.EndBound
+	}
+	return ur, true
+}
+
+// Subtract returns the instants of r not contained in r2. The result holds
+// zero ranges if r2 covers r, two ranges if r2 lies strictly inside r and one
+// range otherwise.
+func (r InstantRange) Subtract(r2 InstantRange) []InstantRange {
+	if r.IsEmpty() {
+		return nil
+	}
+	if !r.Overlaps(r2) {
+		return []InstantRange{r}
+	}
+
+	var result []InstantRange
+	if r.compareStarts(r2) < 0 {
+		sr := r
+		sr.End, sr.EndBound = r2.Start, Bounded
+		result = append(result, sr)
+	}
+	if r.compareEnds(r2) > 0 {
+		sr := r
+		sr.Start, sr.StartBound = r2.End, Bounded
+		result = append(result, sr)
+	}
+	return result
+}
+
+// In returns the instants from the start of d.Start up to the start of the
+// day after d.End in loc. Open endpoints are kept.
+func (d DateRange) In(loc *time.Location) InstantRange {
+	r := InstantRange{StartBound: d.StartBound, EndBound: d.EndBound}
+	if d.hasStart() {
+		r.Start = d.Start.In(loc)
+	}
+	if d.hasEnd() {
+		r.End = d.End.AddDays(1).In(loc)
+	}
+	return r
+}
+
+// NewDateRangeIn returns the days in loc that r touches. Open endpoints are
+// kept. An empty r yields an empty DateRange.
+func NewDateRangeIn(r InstantRange, loc *time.Location) DateRange {
+	if r.IsEmpty() {
+		start := NewDateFromTime(r.Start.In(loc))
+		return DateRange{Start: start, End: start.AddDays(-1)}
+	}
+
+	d := DateRange{StartBound: r.StartBound, EndBound: r.EndBound}
+	if r.hasStart() {
+		d.Start = NewDateFromTime(r.Start.In(loc))
+	}
+	if r.hasEnd() {
+		d.End = NewDateFromTime(r.End.Add(-time.Nanosecond).In(loc))
+	}
+	return d
+}
+
+func (r InstantRange) hasStart() bool {
+	return r.StartBound == Bounded
+}
+
+func (r InstantRange) hasEnd() bool {
+	return r.EndBound == Bounded
+}
+
+// compareStarts compares the lower endpoints of r and r2, treating an open
+// start as smaller than any instant.
+func (r InstantRange) compareStarts(r2 InstantRange) int {
+	switch {
+	case !r.hasStart() && !r2.hasStart():
+		return 0
+	case !r.hasStart():
+		return -1
+	case !r2.hasStart():
+		return +1
+	}
+	return r.Start.Compare(r2.Start)
+}
+
+// compareEnds compares the upper endpoints of r and r2, treating an open end
+// as larger than any instant.
+func (r InstantRange) compareEnds(r2 InstantRange) int {
+	switch {
+	case !r.hasEnd() && !r2.hasEnd():
+		return 0
+	case !r.hasEnd():
+		return +1
+	case !r2.hasEnd():
+		return -1
+	}
+	return r.End.Compare(r2.End)
+}
+
+func (r InstantRange) startsBeforeEnd(r2 InstantRange) bool {
+	return !r.hasStart() || !r2.hasEnd() || r.Start.Before(r2.End)
+}
+
+func (r InstantRange) endsAtStart(r2 InstantRange) bool {
+	return r.hasEnd() && r2.hasStart() && r.End.Equal(r2.Start)
+}
@@ -0,0 +1,217 @@
+package timex
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func instant(hour, minute int) time.Time {
+	return time.Date(2025, 7, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestInstantRangeRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+	tests := []InstantRange{
+		{Start: instant(10, 0), End: instant(11, 30)},
+		{Start: instant(10, 0), EndBound: Unbounded},
+		{StartBound: Unbounded, End: instant(11, 30)},
+		{StartBound: Infinite, EndBound: Infinite},
+		{},
+	}
+
+	for _, oid := range []uint32{pgtype.TstzrangeOID, pgtype.TsrangeOID} {
+		for _, original := range tests {
+			for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+				buf, err := m.Encode(oid, format, original, nil)
+				assert.NoError(t, err)
+
+				var decoded InstantRange
+				err = m.Scan(oid, format, buf, &decoded)
+				assert.NoError(t, err)
+				assertInstantRangeEqual(t, original, decoded)
+			}
+		}
+	}
+
+	var decoded InstantRange
+	err := m.Scan(pgtype.TstzrangeOID, pgtype.TextFormatCode, []byte(`("2025-07-01 10:00:00+00","2025-07-01 11:00:00+00"]`), &decoded)
+	assert.NoError(t, err)
+	assert.True(t, decoded.Start.Equal(instant(10, 0).Add(time.Microsecond)))
+	assert.True(t, decoded.End.Equal(instant(11, 0).Add(time.Microsecond)))
+
+	buf, err := m.Encode(pgtype.TstzrangeOID, pgtype.TextFormatCode, InstantRange{Start: instant(11, 0), End: instant(10, 0)}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "empty", string(buf))
+}
+
+func assertInstantRangeEqual(t *testing.T, want, got InstantRange) {
+	t.Helper()
+	assert.True(t, want.Start.Equal(got.Start), "start: want %s, got %s", want, got)
+	assert.True(t, want.End.Equal(got.End), "end: want %s, got %s", want, got)
+	assert.Equal(t, want.StartBound, got.StartBound)
+	assert.Equal(t, want.EndBound, got.EndBound)
+}
+
+func TestParseInstantRange(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    InstantRange
+		wantStr string
+		wantErr bool
+	}{
+		{
+			str:     "[2025-07-01T10:00:00Z,2025-07-01T11:30:00Z)",
+			want:    InstantRange{Start: instant(10, 0), End: instant(11, 30)},
+			wantStr: "[2025-07-01T10:00:00Z,2025-07-01T11:30:00Z)",
+		},
+		{
+			str:     `["2025-07-01 10:00:00+00","2025-07-01 11:30:00+00")`,
+			want:    InstantRange{Start: instant(10, 0), End: instant(11, 30)},
+			wantStr: "[2025-07-01T10:00:00Z,2025-07-01T11:30:00Z)",
+		},
+		{
+			str:     "2025-07-01T10:00:00Z/2025-07-01T11:30:00Z",
+			want:    InstantRange{Start: instant(10, 0), End: instant(11, 30)},
+			wantStr: "[2025-07-01T10:00:00Z,2025-07-01T11:30:00Z)",
+		},
+		{
+			str:     "2025-07-01T10:00:00Z/..",
+			want:    InstantRange{Start: instant(10, 0), EndBound: Unbounded},
+			wantStr: "[2025-07-01T10:00:00Z,)",
+		},
+		{
+			str:     "[-infinity,infinity)",
+			want:    InstantRange{StartBound: Infinite, EndBound: Infinite},
+			wantStr: "[-infinity,infinity)",
+		},
+		{str: "empty", want: InstantRange{}, wantStr: "empty"},
+		{str: "[2025-07-01T10:00:00Z]", wantErr: true},
+		{str: "[2025-07-01,2025-07-02)", wantErr: true},
+		{str: "2025-07-01T10:00:00Z", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseInstantRange(tt.str)
+		if tt.wantErr {
+			assert.Error(t, err, tt.str)
+			continue
+		}
+		assert.NoError(t, err, tt.str)
+		assertInstantRangeEqual(t, tt.want, got)
+		assert.Equal(t, tt.wantStr, got.String(), tt.str)
+	}
+}
+
+func TestInstantRangeMarshalJSON(t *testing.T) {
+	tests := []struct {
+		r    InstantRange
+		want string
+	}{
+		{InstantRange{Start: instant(10, 0), End: instant(11, 30)}, `{"start":"2025-07-01T10:00:00Z","end":"2025-07-01T11:30:00Z"}`},
+		{InstantRange{Start: instant(10, 0), EndBound: Unbounded}, `{"start":"2025-07-01T10:00:00Z","end":null}`},
+		{InstantRange{StartBound: Infinite, EndBound: Infinite}, `{"start":"-infinity","end":"infinity"}`},
+		{InstantRange{}, `{"start":"0001-01-01T00:00:00Z","end":"0001-01-01T00:00:00Z"}`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.r)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, string(got))
+
+		var decoded InstantRange
+		assert.NoError(t, json.Unmarshal(got, &decoded))
+		assert.Equal(t, tt.r, decoded)
+	}
+
+	var decoded InstantRange
+	assert.NoError(t, json.Unmarshal([]byte(`"2025-07-01T10:00:00Z/2025-07-01T11:30:00Z"`), &decoded))
+	assert.Equal(t, InstantRange{Start: instant(10, 0), End: instant(11, 30)}, decoded)
+
+	// null leaves the range unchanged.
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &decoded))
+	assert.Equal(t, InstantRange{Start: instant(10, 0), End: instant(11, 30)}, decoded)
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"start":null,"end":null}`), &decoded))
+	assert.Equal(t, InstantRange{StartBound: Unbounded, EndBound: Unbounded}, decoded)
+
+	for _, data := range []string{
+		`{}`,
+		`{"start":"2025-07-01T10:00:00Z"}`,
+		`{"start":"2025-07-01T10:00:00Z","ned":"2025-07-01T11:30:00Z"}`,
+	} {
+		assert.Error(t, json.Unmarshal([]byte(data), &decoded), data)
+	}
+}
+
+func TestInstantRangeSetOperations(t *testing.T) {
+	morning := InstantRange{Start: instant(8, 0), End: instant(12, 0)}
+	lunch := InstantRange{Start: instant(12, 0), End: instant(13, 0)}
+	meeting := InstantRange{Start: instant(10, 0), End: instant(11, 0)}
+	fromNoon := InstantRange{Start: instant(12, 0), EndBound: Unbounded}
+	empty := InstantRange{Start: instant(9, 0), End: instant(9, 0)}
+
+	assert.True(t, empty.IsEmpty())
+	assert.False(t, morning.IsEmpty())
+	assert.Equal(t, 4*time.Hour, morning.Duration())
+	assert.Equal(t, time.Duration(0), fromNoon.Duration())
+
+	assert.True(t, morning.Contains(instant(8, 0)))
+	assert.False(t, morning.Contains(instant(12, 0)))
+	assert.True(t, fromNoon.Contains(instant(23, 0)))
+	assert.True(t, morning.ContainsRange(meeting))
+	assert.True(t, morning.ContainsRange(empty))
+	assert.False(t, meeting.ContainsRange(morning))
+
+	assert.False(t, morning.Overlaps(lunch))
+	assert.True(t, morning.Adjacent(lunch))
+	assert.True(t, lunch.Adjacent(morning))
+	assert.True(t, morning.Overlaps(meeting))
+	assert.False(t, morning.Overlaps(empty))
+
+	got, ok := morning.Intersect(InstantRange{Start: instant(11, 0), EndBound: Unbounded})
+	assert.True(t, ok)
+	assert.Equal(t, InstantRange{Start: instant(11, 0), End: instant(12, 0)}, got)
+	_, ok = morning.Intersect(lunch)
+	assert.False(t, ok)
+
+	got, ok = morning.Union(fromNoon)
+	assert.True(t, ok)
+	assert.Equal(t, InstantRange{Start: instant(8, 0), EndBound: Unbounded}, got)
+	_, ok = meeting.Union(lunch)
+	assert.False(t, ok)
+	got, ok = meeting.Union(empty)
+	assert.True(t, ok)
+	assert.Equal(t, meeting, got)
+
+	assert.Equal(t, []InstantRange{
+		{Start: instant(8, 0), End: instant(10, 0)},
+		{Start: instant(11, 0), End: instant(12, 0)},
+	}, morning.Subtract(meeting))
+	assert.Equal(t, []InstantRange{morning}, morning.Subtract(lunch))
+	assert.Empty(t, meeting.Subtract(morning))
+}
+
+func TestInstantRangeDateRangeConversion(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	assert.NoError(t, err)
+
+	d := DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 3}}
+	r := d.In(rome)
+	assert.True(t, r.Start.Equal(time.Date(2025, 6, 30, 22, 0, 0, 0, time.UTC)))
+	assert.True(t, r.End.Equal(time.Date(2025, 7, 3, 22, 0, 0, 0, time.UTC)))
+	assert.Equal(t, d, NewDateRangeIn(r, rome))
+
+	open := DateRange{Start: Date{2025, 7, 1}, EndBound: Unbounded}
+	assert.Equal(t, open, NewDateRangeIn(open.In(rome), rome))
+
+	// 23:30 UTC on 1 July is already 2 July in Rome.
+	late := InstantRange{Start: instant(23, 30), End: instant(23, 45)}
+	assert.Equal(t, DateRange{Start: Date{2025, 7, 2}, End: Date{2025, 7, 2}}, NewDateRangeIn(late, rome))
+	assert.Equal(t, DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 1}}, NewDateRangeIn(late, time.UTC))
+
+	assert.True(t, NewDateRangeIn(InstantRange{Start: instant(10, 0), End: instant(10, 0)}, rome).IsEmpty())
+}
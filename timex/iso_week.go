@@ -0,0 +1,132 @@
+package timex
+
+import (
+	"encoding"
+	"fmt"
+	"iter"
+	"strconv"
+	"time"
+)
+
+// ISOWeek is a week as defined by ISO 8601, e.g. 2025-W27. Weeks start on
+// Monday and week 1 is the week containing the year's first Thursday, so the
+// first and last days of a year may belong to a week of another year.
+type ISOWeek struct {
+	Year int
+	Week int
+}
+
+func ParseISOWeek(s string) (ISOWeek, error) {
+	if len(s) != 8 || s[4:6] != "-W" || !isDigits(s[:4]) || !isDigits(s[6:]) {
+		return ISOWeek{}, fmt.Errorf("invalid ISO week %q", s)
+	}
+	year, _ := strconv.Atoi(s[:4])
+	week, _ := strconv.Atoi(s[6:])
+	w := ISOWeek{Year: year, Week: week}
+	if w.Week < 1 || w.FirstDay().ISOWeek() != w {
+		return ISOWeek{}, fmt.Errorf("invalid ISO week %q", s)
+	}
+	return w, nil
+}
+
+func (d Date) ISOWeek() ISOWeek {
+	var w ISOWeek
+	w.Year, w.Week = d.In(time.UTC).ISOWeek()
+	return w
+}
+
+// FirstDay returns the Monday of w.
+func (w ISOWeek) FirstDay() Date {
+	// January 4th is always in week 1.
+	jan4 := Date{w.Year, time.January, 4}
+	offset := (int(jan4.Weekday()) + 6) % 7
+	return jan4.AddDays(-offset + (w.Week-1)*7)
+}
+
+// LastDay returns the Sunday of w.
+func (w ISOWeek) LastDay() Date {
+	return w.FirstDay().AddDays(6)
+}
+
+func (w ISOWeek) Contains(d Date) bool {
+	return d.ISOWeek() == w
+}
+
+func (w ISOWeek) DateRange() DateRange {
+	return DateRange{Start: w.FirstDay(), End: w.LastDay()}
+}
+
+func (w ISOWeek) AddWeeks(n int) ISOWeek {
+	return w.FirstDay().AddDays(n * 7).ISOWeek()
+}
+
+func (w ISOWeek) Before(w2 ISOWeek) bool {
+	if w.Year != w2.Year {
+		return w.Year < w2.Year
+	}
+	return w.Week < w2.Week
+}
+
+func (w ISOWeek) After(w2 ISOWeek) bool {
+	return w2.Before(w)
+}
+
+func (w ISOWeek) Compare(w2 ISOWeek) int {
+	if w.Before(w2) {
+		return -1
+	}
+	if w.After(w2) {
+		return +1
+	}
+	return 0
+}
+
+func (w ISOWeek) IsZero() bool {
+	return w == ISOWeek{}
+}
+
+func (w ISOWeek) String() string {
+	if w.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d-W%02d", w.Year, w.Week)
+}
+
+func (w ISOWeek) MarshalText() ([]byte, error) {
+	return []byte(w.String()), nil
+}
+
+func (w *ISOWeek) UnmarshalText(data []byte) error {
+	var err error
+	*w, err = ParseISOWeek(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = ISOWeek{}
+var _ encoding.TextUnmarshaler = &ISOWeek{}
+
+// Weeks returns an iterator over the parts of d falling into each ISO week, in
+// ascending order. It yields nothing if d has an open start and never stops
+// if d has an open end.
+func (d DateRange) Weeks() iter.Seq2[ISOWeek, DateRange] {
+	return func(yield func(ISOWeek, DateRange) bool) {
+		if !d.hasStart() || d.IsEmpty() {
+			return
+		}
+		for w := d.Start.ISOWeek(); d.Contains(w.FirstDay()) || w.Contains(d.Start); w = w.AddWeeks(1) {
+			part, _ := d.Intersect(w.DateRange())
+			if !yield(w, part) {
+				return
+			}
+		}
+	}
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,112 @@
+package timex
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseISOWeek(t *testing.T) {
+	tests := []struct {
+		str  string
+		want ISOWeek // if zero, expect an error
+	}{
+		{"2025-W27", ISOWeek{2025, 27}},
+		{"2025-W01", ISOWeek{2025, 1}},
+		{"2020-W53", ISOWeek{2020, 53}},
+		{"2025-W53", ISOWeek{}},
+		{"2025-W00", ISOWeek{}},
+		{"2025-W2x", ISOWeek{}},
+		{"2025-27", ISOWeek{}},
+		{"2025W27", ISOWeek{}},
+		{"", ISOWeek{}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseISOWeek(tt.str)
+		assert.Equal(t, tt.want, got, tt.str)
+		if got.IsZero() {
+			assert.Error(t, err, tt.str)
+		}
+	}
+}
+
+func TestDateISOWeek(t *testing.T) {
+	tests := []struct {
+		date Date
+		want ISOWeek
+	}{
+		{Date{2025, 7, 1}, ISOWeek{2025, 27}},
+		{Date{2024, 12, 30}, ISOWeek{2025, 1}},
+		{Date{2021, 1, 3}, ISOWeek{2020, 53}},
+		{Date{2021, 1, 4}, ISOWeek{2021, 1}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.date.ISOWeek(), tt.date.String())
+	}
+}
+
+func TestISOWeekDays(t *testing.T) {
+	tests := []struct {
+		w                 ISOWeek
+		firstDay, lastDay Date
+	}{
+		{ISOWeek{2025, 27}, Date{2025, 6, 30}, Date{2025, 7, 6}},
+		{ISOWeek{2025, 1}, Date{2024, 12, 30}, Date{2025, 1, 5}},
+		{ISOWeek{2020, 53}, Date{2020, 12, 28}, Date{2021, 1, 3}},
+		{ISOWeek{2026, 1}, Date{2025, 12, 29}, Date{2026, 1, 4}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.firstDay, tt.w.FirstDay(), tt.w.String())
+		assert.Equal(t, tt.lastDay, tt.w.LastDay(), tt.w.String())
+		assert.Equal(t, DateRange{Start: tt.firstDay, End: tt.lastDay}, tt.w.DateRange())
+		assert.True(t, tt.w.Contains(tt.firstDay))
+		assert.True(t, tt.w.Contains(tt.lastDay))
+		assert.False(t, tt.w.Contains(tt.lastDay.AddDays(1)))
+	}
+}
+
+func TestISOWeekAddWeeks(t *testing.T) {
+	assert.Equal(t, ISOWeek{2021, 1}, ISOWeek{2020, 53}.AddWeeks(1))
+	assert.Equal(t, ISOWeek{2025, 1}, ISOWeek{2024, 52}.AddWeeks(1))
+	assert.Equal(t, ISOWeek{2024, 52}, ISOWeek{2025, 1}.AddWeeks(-1))
+	assert.Equal(t, ISOWeek{2026, 27}, ISOWeek{2025, 27}.AddWeeks(52))
+}
+
+func TestISOWeekCompare(t *testing.T) {
+	assert.Equal(t, -1, ISOWeek{2020, 53}.Compare(ISOWeek{2021, 1}))
+	assert.Equal(t, 0, ISOWeek{2025, 27}.Compare(ISOWeek{2025, 27}))
+	assert.Equal(t, +1, ISOWeek{2025, 28}.Compare(ISOWeek{2025, 27}))
+}
+
+func TestISOWeekMarshalJSON(t *testing.T) {
+	got, err := json.Marshal(ISOWeek{2025, 7})
+	assert.NoError(t, err)
+	assert.Equal(t, `"2025-W07"`, string(got))
+
+	var w ISOWeek
+	assert.NoError(t, json.Unmarshal(got, &w))
+	assert.Equal(t, ISOWeek{2025, 7}, w)
+}
+
+func TestDateRangeWeeks(t *testing.T) {
+	// 2025-07-01 is a Tuesday, 2025-07-15 a Tuesday.
+	r := DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 15}}
+
+	var weeks []ISOWeek
+	var parts []DateRange
+	for w, part := range r.Weeks() {
+		weeks = append(weeks, w)
+		parts = append(parts, part)
+	}
+
+	assert.Equal(t, []ISOWeek{{2025, 27}, {2025, 28}, {2025, 29}}, weeks)
+	assert.Equal(t, []DateRange{
+		{Start: Date{2025, 7, 1}, End: Date{2025, 7, 6}},
+		{Start: Date{2025, 7, 7}, End: Date{2025, 7, 13}},
+		{Start: Date{2025, 7, 14}, End: Date{2025, 7, 15}},
+	}, parts)
+}
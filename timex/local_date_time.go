@@ -0,0 +1,164 @@
+package timex
+
+import (
+	"encoding"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// LocalDateTime is a civil date and time of day without a time zone, e.g. a
+// check-in at 2025-07-01 15:00 wherever the hotel is located.
+type LocalDateTime struct {
+	Date Date
+	Time Time
+}
+
+const localDateTimeLayout = "2006-01-02T15:04:05"
+
+// NewLocalDateTime returns the wall clock date and time of t in t's location.
+func NewLocalDateTime(t time.Time) LocalDateTime {
+	return LocalDateTime{
+		Date: NewDateFromTime(t),
+		Time: NewTime(t),
+	}
+}
+
+// NewLocalDateTimeIn returns the wall clock date and time of t in loc.
+func NewLocalDateTimeIn(t time.Time, loc *time.Location) LocalDateTime {
+	return NewLocalDateTime(t.In(loc))
+}
+
+// ParseLocalDateTime parses an ISO 8601 date and time without offset, e.g.
+// 2025-07-01T15:00:00. A space is accepted in place of the T separator.
+func ParseLocalDateTime(s string) (LocalDateTime, error) {
+	if len(s) > 10 && s[10] == ' ' {
+		s = s[:10] + "T" + s[11:]
+	}
+	t, err := time.Parse(localDateTimeLayout, s)
+	if err != nil {
+		return LocalDateTime{}, err
+	}
+	return NewLocalDateTime(t), nil
+}
+
+// In returns the instant at which the wall clock in loc shows dt.
+//
+// If dt falls into a gap, e.g. when clocks are turned forward for daylight
+// saving time, the result is moved forward by the length of the gap, so
+// 02:30 becomes 03:30 on a day where 02:00 jumps to 03:00. If dt is
+// ambiguous because clocks are turned back, the earlier of the two instants is
+// returned.
+func (dt LocalDateTime) In(loc *time.Location) time.Time {
+	wall := dt.in(time.UTC)
+
+	offsetBefore := offsetAt(wall.Add(-Day), loc)
+	offsetAfter := offsetAt(wall.Add(Day), loc)
+
+	var candidates []time.Time
+	for _, offset := range []int{offsetBefore, offsetAfter} {
+		t := wall.Add(-time.Duration(offset) * time.Second)
+		if offsetAt(t, loc) == offset {
+			candidates = append(candidates, t.In(loc))
+		}
+	}
+
+	switch {
+	case len(candidates) == 0:
+		// dt lies in a gap: interpret it with the offset in effect before the
+		// transition, which moves it forward by the length of the gap.
+		return wall.Add(-time.Duration(offsetBefore) * time.Second).In(loc)
+	case len(candidates) == 2 && candidates[1].Before(candidates[0]):
+		return candidates[1]
+	default:
+		return candidates[0]
+	}
+}
+
+func (dt LocalDateTime) in(loc *time.Location) time.Time {
+	return time.Date(dt.Date.Year, dt.Date.Month, dt.Date.Day, dt.Time.Hour, dt.Time.Minute, dt.Time.Second, 0, loc)
+}
+
+func offsetAt(t time.Time, loc *time.Location) int {
+	_, offset := t.In(loc).Zone()
+	return offset
+}
+
+// Add returns dt+d on the civil clock, ignoring time zone transitions.
+func (dt LocalDateTime) Add(d time.Duration) LocalDateTime {
+	return NewLocalDateTime(dt.in(time.UTC).Add(d))
+}
+
+// Sub returns the civil duration dt-dt2, ignoring time zone transitions.
+func (dt LocalDateTime) Sub(dt2 LocalDateTime) time.Duration {
+	return dt.in(time.UTC).Sub(dt2.in(time.UTC))
+}
+
+func (dt LocalDateTime) Before(dt2 LocalDateTime) bool {
+	if dt.Date != dt2.Date {
+		return dt.Date.Before(dt2.Date)
+	}
+	return dt.Time.Before(dt2.Time)
+}
+
+func (dt LocalDateTime) After(dt2 LocalDateTime) bool {
+	return dt2.Before(dt)
+}
+
+func (dt LocalDateTime) Compare(dt2 LocalDateTime) int {
+	if dt.Before(dt2) {
+		return -1
+	}
+	if dt.After(dt2) {
+		return +1
+	}
+	return 0
+}
+
+func (dt LocalDateTime) IsZero() bool {
+	return dt.Date.IsZero() && dt.Time.IsZero()
+}
+
+func (dt LocalDateTime) String() string {
+	if dt.IsZero() {
+		return ""
+	}
+	return dt.Date.String() + "T" + dt.Time.String()
+}
+
+func (dt LocalDateTime) MarshalText() ([]byte, error) {
+	return []byte(dt.String()), nil
+}
+
+func (dt *LocalDateTime) UnmarshalText(data []byte) error {
+	var err error
+	*dt, err = ParseLocalDateTime(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = LocalDateTime{}
+var _ encoding.TextUnmarshaler = &LocalDateTime{}
+
+func (dt *LocalDateTime) ScanTimestamp(v pgtype.Timestamp) error {
+	if !v.Valid {
+		*dt = LocalDateTime{}
+		return nil
+	}
+	if v.InfinityModifier != pgtype.Finite {
+		return errors.New("cannot scan infinite timestamp into LocalDateTime")
+	}
+
+	*dt = NewLocalDateTime(v.Time)
+	return nil
+}
+
+func (dt LocalDateTime) TimestampValue() (pgtype.Timestamp, error) {
+	return pgtype.Timestamp{
+		Time:  dt.in(time.UTC),
+		Valid: true,
+	}, nil
+}
+
+var _ pgtype.TimestampScanner = &LocalDateTime{}
+var _ pgtype.TimestampValuer = LocalDateTime{}
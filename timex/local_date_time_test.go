@@ -0,0 +1,161 @@
+package timex
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocalDateTime(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    LocalDateTime
+		wantErr bool
+	}{
+		{str: "2025-07-01T15:00:00", want: LocalDateTime{Date{2025, 7, 1}, Time{Hour: 15}}},
+		{str: "2025-07-01 15:04:05", want: LocalDateTime{Date{2025, 7, 1}, Time{Hour: 15, Minute: 4, Second: 5}}},
+		{str: "2025-07-01", wantErr: true},
+		{str: "2025-07-01T15:00:00Z", wantErr: true},
+		{str: "2025-07-01T15:00:00+02:00", wantErr: true},
+		{str: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLocalDateTime(tt.str)
+		if tt.wantErr {
+			assert.Error(t, err, tt.str)
+			continue
+		}
+		assert.NoError(t, err, tt.str)
+		assert.Equal(t, tt.want, got, tt.str)
+	}
+}
+
+func TestNewLocalDateTimeIn(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	assert.NoError(t, err)
+
+	instant := time.Date(2025, 6, 30, 22, 30, 0, 0, time.UTC)
+	assert.Equal(t, LocalDateTime{Date{2025, 7, 1}, Time{Hour: 0, Minute: 30}}, NewLocalDateTimeIn(instant, rome))
+	assert.Equal(t, LocalDateTime{Date{2025, 6, 30}, Time{Hour: 22, Minute: 30}}, NewLocalDateTime(instant))
+}
+
+func TestLocalDateTimeIn(t *testing.T) {
+	rome, err := time.LoadLocation("Europe/Rome")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc string
+		dt   LocalDateTime
+		want time.Time
+	}{
+		{
+			desc: "summer time",
+			dt:   LocalDateTime{Date{2025, 7, 1}, Time{Hour: 15}},
+			want: time.Date(2025, 7, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "standard time",
+			dt:   LocalDateTime{Date{2025, 1, 1}, Time{Hour: 15}},
+			want: time.Date(2025, 1, 1, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "gap moves forward",
+			dt:   LocalDateTime{Date{2025, 3, 30}, Time{Hour: 2, Minute: 30}},
+			want: time.Date(2025, 3, 30, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			desc: "just before the gap",
+			dt:   LocalDateTime{Date{2025, 3, 30}, Time{Hour: 1, Minute: 59, Second: 59}},
+			want: time.Date(2025, 3, 30, 0, 59, 59, 0, time.UTC),
+		},
+		{
+			desc: "just after the gap",
+			dt:   LocalDateTime{Date{2025, 3, 30}, Time{Hour: 3}},
+			want: time.Date(2025, 3, 30, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "overlap picks the earlier instant",
+			dt:   LocalDateTime{Date{2025, 10, 26}, Time{Hour: 2, Minute: 30}},
+			want: time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC),
+		},
+		{
+			desc: "after the overlap",
+			dt:   LocalDateTime{Date{2025, 10, 26}, Time{Hour: 3}},
+			want: time.Date(2025, 10, 26, 2, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		got := tt.dt.In(rome)
+		assert.True(t, tt.want.Equal(got), "%s: got %s", tt.desc, got.UTC())
+		assert.Equal(t, rome, got.Location(), tt.desc)
+	}
+
+	dt := LocalDateTime{Date{2025, 7, 1}, Time{Hour: 15}}
+	assert.True(t, dt.In(time.UTC).Equal(time.Date(2025, 7, 1, 15, 0, 0, 0, time.UTC)))
+}
+
+func TestLocalDateTimeArithmetic(t *testing.T) {
+	dt := LocalDateTime{Date{2025, 12, 31}, Time{Hour: 23, Minute: 30}}
+
+	assert.Equal(t, LocalDateTime{Date{2026, 1, 1}, Time{Hour: 0, Minute: 30}}, dt.Add(time.Hour))
+	assert.Equal(t, LocalDateTime{Date{2025, 12, 30}, Time{Hour: 23, Minute: 30}}, dt.Add(-Day))
+	assert.Equal(t, time.Hour, dt.Add(time.Hour).Sub(dt))
+}
+
+func TestLocalDateTimeCompare(t *testing.T) {
+	tests := []struct {
+		dt1, dt2 LocalDateTime
+		want     int
+	}{
+		{LocalDateTime{Date{2025, 7, 1}, Time{Hour: 15}}, LocalDateTime{Date{2025, 7, 2}, Time{Hour: 10}}, -1},
+		{LocalDateTime{Date{2025, 7, 1}, Time{Hour: 15}}, LocalDateTime{Date{2025, 7, 1}, Time{Hour: 16}}, -1},
+		{LocalDateTime{Date{2025, 7, 1}, Time{Hour: 15}}, LocalDateTime{Date{2025, 7, 1}, Time{Hour: 15}}, 0},
+		{LocalDateTime{Date{2025, 7, 2}, Time{Hour: 10}}, LocalDateTime{Date{2025, 7, 1}, Time{Hour: 15}}, +1},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.dt1.Compare(tt.dt2))
+		assert.Equal(t, tt.want < 0, tt.dt1.Before(tt.dt2))
+		assert.Equal(t, tt.want > 0, tt.dt1.After(tt.dt2))
+	}
+}
+
+func TestLocalDateTimeMarshalJSON(t *testing.T) {
+	dt := LocalDateTime{Date{2025, 7, 1}, Time{Hour: 15, Minute: 4, Second: 5}}
+
+	got, err := json.Marshal(dt)
+	assert.NoError(t, err)
+	assert.Equal(t, `"2025-07-01T15:04:05"`, string(got))
+
+	var decoded LocalDateTime
+	assert.NoError(t, json.Unmarshal(got, &decoded))
+	assert.Equal(t, dt, decoded)
+
+	got, err = json.Marshal(LocalDateTime{})
+	assert.NoError(t, err)
+	assert.Equal(t, `""`, string(got))
+}
+
+func TestLocalDateTimeTimestampRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+	original := LocalDateTime{Date{2025, 7, 1}, Time{Hour: 15, Minute: 4, Second: 5}}
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(pgtype.TimestampOID, format, original, nil)
+		assert.NoError(t, err)
+
+		var decoded LocalDateTime
+		err = m.Scan(pgtype.TimestampOID, format, buf, &decoded)
+		assert.NoError(t, err)
+		assert.Equal(t, original, decoded)
+	}
+
+	var decoded LocalDateTime
+	err := m.Scan(pgtype.TimestampOID, pgtype.TextFormatCode, []byte("infinity"), &decoded)
+	assert.Error(t, err)
+}
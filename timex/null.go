@@ -0,0 +1,184 @@
+package timex
+
+import (
+	"encoding"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NullDate is a Date that may be NULL, e.g. to tell a missing date apart
+// from the zero Date.
+type NullDate struct {
+	Date  Date
+	Valid bool
+}
+
+// NullDateFromPtr returns a NullDate that is valid if p is not nil.
+func NullDateFromPtr(p *Date) NullDate {
+	d, valid := fromPtr(p)
+	return NullDate{Date: d, Valid: valid}
+}
+
+// Ptr returns a pointer to nd.Date or nil if nd is not valid.
+func (nd NullDate) Ptr() *Date {
+	return toPtr(nd.Date, nd.Valid)
+}
+
+func (nd *NullDate) ScanDate(v pgtype.Date) error {
+	if !v.Valid {
+		*nd = NullDate{}
+		return nil
+	}
+	nd.Valid = true
+	return nd.Date.ScanDate(v)
+}
+
+func (nd NullDate) DateValue() (pgtype.Date, error) {
+	if !nd.Valid {
+		return pgtype.Date{}, nil
+	}
+	return nd.Date.DateValue()
+}
+
+var _ pgtype.DateScanner = &NullDate{}
+var _ pgtype.DateValuer = NullDate{}
+
+func (nd NullDate) MarshalText() ([]byte, error) {
+	return marshalNullText(nd.Date, nd.Valid)
+}
+
+func (nd *NullDate) UnmarshalText(data []byte) error {
+	return unmarshalNullText(data, &nd.Date, &nd.Valid)
+}
+
+var _ encoding.TextMarshaler = NullDate{}
+var _ encoding.TextUnmarshaler = &NullDate{}
+
+func (nd NullDate) MarshalJSON() ([]byte, error) {
+	return marshalNullJSON(nd.Date, nd.Valid)
+}
+
+func (nd *NullDate) UnmarshalJSON(data []byte) error {
+	return unmarshalNullJSON(data, &nd.Date, &nd.Valid)
+}
+
+var _ json.Marshaler = NullDate{}
+var _ json.Unmarshaler = &NullDate{}
+
+// NullTime is a Time that may be NULL, e.g. to tell a missing time apart
+// from midnight.
+type NullTime struct {
+	Time  Time
+	Valid bool
+}
+
+// NullTimeFromPtr returns a NullTime that is valid if p is not nil.
+func NullTimeFromPtr(p *Time) NullTime {
+	t, valid := fromPtr(p)
+	return NullTime{Time: t, Valid: valid}
+}
+
+// Ptr returns a pointer to nt.Time or nil if nt is not valid.
+func (nt NullTime) Ptr() *Time {
+	return toPtr(nt.Time, nt.Valid)
+}
+
+func (nt *NullTime) ScanTime(v pgtype.Time) error {
+	if !v.Valid {
+		*nt = NullTime{}
+		return nil
+	}
+	nt.Valid = true
+	return nt.Time.ScanTime(v)
+}
+
+func (nt NullTime) TimeValue() (pgtype.Time, error) {
+	if !nt.Valid {
+		return pgtype.Time{}, nil
+	}
+	return nt.Time.TimeValue()
+}
+
+var _ pgtype.TimeScanner = &NullTime{}
+var _ pgtype.TimeValuer = NullTime{}
+
+func (nt NullTime) MarshalText() ([]byte, error) {
+	return marshalNullText(nt.Time, nt.Valid)
+}
+
+func (nt *NullTime) UnmarshalText(data []byte) error {
+	return unmarshalNullText(data, &nt.Time, &nt.Valid)
+}
+
+var _ encoding.TextMarshaler = NullTime{}
+var _ encoding.TextUnmarshaler = &NullTime{}
+
+func (nt NullTime) MarshalJSON() ([]byte, error) {
+	return marshalNullJSON(nt.Time, nt.Valid)
+}
+
+func (nt *NullTime) UnmarshalJSON(data []byte) error {
+	return unmarshalNullJSON(data, &nt.Time, &nt.Valid)
+}
+
+var _ json.Marshaler = NullTime{}
+var _ json.Unmarshaler = &NullTime{}
+
+func toPtr[T any](v T, valid bool) *T {
+	if !valid {
+		return nil
+	}
+	return &v
+}
+
+func fromPtr[T any](p *T) (T, bool) {
+	if p == nil {
+		var zero T
+		return zero, false
+	}
+	return *p, true
+}
+
+func marshalNullText(v encoding.TextMarshaler, valid bool) ([]byte, error) {
+	if !valid {
+		return []byte{}, nil
+	}
+	return v.MarshalText()
+}
+
+func unmarshalNullText[T any, PT interface {
+	*T
+	encoding.TextUnmarshaler
+}](data []byte, v PT, valid *bool) error {
+	var zero T
+	*v, *valid = zero, false
+	if len(data) == 0 {
+		return nil
+	}
+	if err := v.UnmarshalText(data); err != nil {
+		return err
+	}
+	*valid = true
+	return nil
+}
+
+func marshalNullJSON(v any, valid bool) ([]byte, error) {
+	if !valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v)
+}
+
+func unmarshalNullJSON[T any](data []byte, v *T, valid *bool) error {
+	var zero T
+	*v, *valid = zero, false
+	if string(data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return err
+	}
+	*valid = true
+	return nil
+}
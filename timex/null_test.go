@@ -0,0 +1,116 @@
+package timex
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNullDateRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, original := range []NullDate{{}, {Date{2025, 7, 1}, true}} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			buf, err := m.Encode(pgtype.DateOID, format, original, nil)
+			assert.NoError(t, err)
+
+			decoded := NullDate{Date{2000, 1, 1}, true}
+			err = m.Scan(pgtype.DateOID, format, buf, &decoded)
+			assert.NoError(t, err)
+			assert.Equal(t, original, decoded)
+		}
+	}
+}
+
+func TestNullTimeRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, original := range []NullTime{{}, {Time{}, true}, {Time{13, 26, 33}, true}} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			buf, err := m.Encode(pgtype.TimeOID, format, original, nil)
+			assert.NoError(t, err)
+
+			decoded := NullTime{Time{Hour: 1}, true}
+			err = m.Scan(pgtype.TimeOID, format, buf, &decoded)
+			assert.NoError(t, err)
+			assert.Equal(t, original, decoded)
+		}
+	}
+}
+
+func TestNullDateMarshal(t *testing.T) {
+	tests := []struct {
+		nd   NullDate
+		text string
+		json string
+	}{
+		{NullDate{}, "", "null"},
+		{NullDate{Date{2025, 7, 1}, true}, "2025-07-01", `"2025-07-01"`},
+	}
+
+	for _, tt := range tests {
+		text, err := tt.nd.MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, tt.text, string(text))
+
+		js, err := json.Marshal(tt.nd)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.json, string(js))
+
+		decoded := NullDate{Date{2000, 1, 1}, true}
+		assert.NoError(t, decoded.UnmarshalText(text))
+		assert.Equal(t, tt.nd, decoded)
+
+		decoded = NullDate{Date{2000, 1, 1}, true}
+		assert.NoError(t, json.Unmarshal(js, &decoded))
+		assert.Equal(t, tt.nd, decoded)
+	}
+
+	var nd NullDate
+	assert.Error(t, json.Unmarshal([]byte(`"2025-13-01"`), &nd))
+	assert.False(t, nd.Valid)
+}
+
+func TestNullTimeMarshal(t *testing.T) {
+	tests := []struct {
+		nt   NullTime
+		text string
+		json string
+	}{
+		{NullTime{}, "", "null"},
+		{NullTime{Time{}, true}, "00:00:00", `"00:00:00"`},
+		{NullTime{Time{Hour: 15, Minute: 30}, true}, "15:30:00", `"15:30:00"`},
+	}
+
+	for _, tt := range tests {
+		text, err := tt.nt.MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, tt.text, string(text))
+
+		js, err := json.Marshal(tt.nt)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.json, string(js))
+
+		decoded := NullTime{Time{Hour: 1}, true}
+		assert.NoError(t, decoded.UnmarshalText(text))
+		assert.Equal(t, tt.nt, decoded)
+
+		decoded = NullTime{Time{Hour: 1}, true}
+		assert.NoError(t, json.Unmarshal(js, &decoded))
+		assert.Equal(t, tt.nt, decoded)
+	}
+}
+
+func TestNullPtr(t *testing.T) {
+	assert.Nil(t, NullDate{}.Ptr())
+	assert.Equal(t, &Date{2025, 7, 1}, NullDate{Date{2025, 7, 1}, true}.Ptr())
+	assert.Equal(t, NullDate{}, NullDateFromPtr(nil))
+	assert.Equal(t, NullDate{Date{2025, 7, 1}, true}, NullDateFromPtr(&Date{2025, 7, 1}))
+
+	assert.Nil(t, NullTime{}.Ptr())
+	assert.Equal(t, &Time{}, NullTime{Time{}, true}.Ptr())
+	assert.Equal(t, NullTime{}, NullTimeFromPtr(nil))
+	assert.Equal(t, NullTime{Time{}, true}, NullTimeFromPtr(&Time{}))
+}
@@ -0,0 +1,124 @@
+package timex
+
+import (
+	"encoding"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PreciseTime is a Time with fractions of a second. Postgres time columns
+// store microseconds, which round-trip through PreciseTime without loss.
+type PreciseTime struct {
+	Time
+	Nanosecond int
+}
+
+func NewPreciseTime(t time.Time) PreciseTime {
+	return PreciseTime{Time: NewTime(t), Nanosecond: t.Nanosecond()}
+}
+
+// ParsePreciseTime parses a time of day in the form 15:04, 15:04:05 or
+// 15:04:05.999999999.
+func ParsePreciseTime(s string) (PreciseTime, error) {
+	t, err := parseClock(s)
+	if err != nil {
+		return PreciseTime{}, err
+	}
+	return NewPreciseTime(t), nil
+}
+
+// String returns the time in the form 15:04:05, followed by the fractional
+// seconds without trailing zeros if there are any.
+func (t PreciseTime) String() string {
+	s := t.Time.String()
+	if t.Nanosecond != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
+	}
+	return s
+}
+
+func (t PreciseTime) IsZero() bool {
+	return t.Time.IsZero() && t.Nanosecond == 0
+}
+
+func (t PreciseTime) Before(t2 PreciseTime) bool {
+	if t.Time != t2.Time {
+		return t.Time.Before(t2.Time)
+	}
+	return t.Nanosecond < t2.Nanosecond
+}
+
+func (t PreciseTime) After(t2 PreciseTime) bool {
+	return t2.Before(t)
+}
+
+func (t PreciseTime) Compare(t2 PreciseTime) int {
+	if t.Before(t2) {
+		return -1
+	} else if t.After(t2) {
+		return +1
+	}
+	return 0
+}
+
+// Add returns t+d on a 24 hour clock, wrapping around midnight in either
+// direction.
+func (t PreciseTime) Add(d time.Duration) PreciseTime {
+	d = (t.sinceMidnight() + d%Day + Day) % Day
+	return PreciseTime{Time: newTimeFromDuration(d), Nanosecond: int(d % time.Second)}
+}
+
+// Sub returns the duration t-t2 within the same day. The result is negative
+// if t is before t2.
+func (t PreciseTime) Sub(t2 PreciseTime) time.Duration {
+	return t.sinceMidnight() - t2.sinceMidnight()
+}
+
+func (t PreciseTime) sinceMidnight() time.Duration {
+	return t.Time.sinceMidnight() + time.Duration(t.Nanosecond)
+}
+
+func (t PreciseTime) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *PreciseTime) UnmarshalText(data []byte) error {
+	var err error
+	*t, err = ParsePreciseTime(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = PreciseTime{}
+var _ encoding.TextUnmarshaler = &PreciseTime{}
+
+func (t *PreciseTime) ScanTime(v pgtype.Time) error {
+	if !v.Valid {
+		*t = PreciseTime{}
+		return nil
+	}
+
+	*t = PreciseTime{
+		Time:       newTimeFromMicroseconds(v.Microseconds),
+		Nanosecond: int(v.Microseconds%1_000_000) * 1000,
+	}
+	return nil
+}
+
+// TimeValue truncates any nanoseconds beyond the microsecond precision of
+// Postgres.
+func (t PreciseTime) TimeValue() (pgtype.Time, error) {
+	return pgtype.Time{
+		Microseconds: t.microseconds(),
+		Valid:        true,
+	}, nil
+}
+
+var _ pgtype.TimeScanner = &PreciseTime{}
+var _ pgtype.TimeValuer = PreciseTime{}
+
+func (t PreciseTime) microseconds() int64 {
+	return t.Time.microseconds() + int64(t.Nanosecond/1000)
+}
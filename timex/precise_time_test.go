@@ -0,0 +1,101 @@
+package timex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPreciseTime(t *testing.T) {
+	tm := time.Date(2025, 7, 1, 13, 26, 33, 123_456_789, time.UTC)
+	assert.Equal(t, PreciseTime{Time{13, 26, 33}, 123_456_789}, NewPreciseTime(tm))
+	assert.Equal(t, Time{13, 26, 33}, NewTime(tm))
+}
+
+func TestParsePreciseTime(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    PreciseTime
+		wantErr bool
+	}{
+		{str: "13:26", want: PreciseTime{Time: Time{13, 26, 0}}},
+		{str: "13:26:33", want: PreciseTime{Time: Time{13, 26, 33}}},
+		{str: "13:26:33.5", want: PreciseTime{Time{13, 26, 33}, 500_000_000}},
+		{str: "13:26:33.123456", want: PreciseTime{Time{13, 26, 33}, 123_456_000}},
+		{str: "13:26:33.123456789", want: PreciseTime{Time{13, 26, 33}, 123_456_789}},
+		{str: "25:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePreciseTime(tt.str)
+		if tt.wantErr {
+			assert.Error(t, err, tt.str)
+			continue
+		}
+		assert.NoError(t, err, tt.str)
+		assert.Equal(t, tt.want, got, tt.str)
+	}
+}
+
+func TestPreciseTimeString(t *testing.T) {
+	assert.Equal(t, "13:26:33", PreciseTime{Time: Time{13, 26, 33}}.String())
+	assert.Equal(t, "13:26:33.5", PreciseTime{Time{13, 26, 33}, 500_000_000}.String())
+	assert.Equal(t, "13:26:33.000001", PreciseTime{Time{13, 26, 33}, 1000}.String())
+	assert.Equal(t, "13:26:33.123456789", PreciseTime{Time{13, 26, 33}, 123_456_789}.String())
+}
+
+func TestPreciseTimeCompare(t *testing.T) {
+	a := PreciseTime{Time{13, 26, 33}, 0}
+	b := PreciseTime{Time{13, 26, 33}, 1}
+	c := PreciseTime{Time{13, 26, 34}, 0}
+
+	assert.True(t, a.Before(b))
+	assert.True(t, b.Before(c))
+	assert.True(t, c.After(a))
+	assert.Equal(t, 0, a.Compare(a))
+	assert.False(t, PreciseTime{Nanosecond: 1}.IsZero())
+	assert.True(t, PreciseTime{}.IsZero())
+}
+
+func TestPreciseTimeAdd(t *testing.T) {
+	tm := PreciseTime{Time: Time{12, 0, 0}}
+	assert.Equal(t, PreciseTime{Time{11, 59, 59}, 999_999_999}, tm.Add(-Day-time.Nanosecond))
+	assert.Equal(t, PreciseTime{Time{12, 0, 1}, 500_000_000}, tm.Add(1500*time.Millisecond))
+	assert.Equal(t, 1500*time.Millisecond, PreciseTime{Time{12, 0, 1}, 500_000_000}.Sub(tm))
+}
+
+func TestPreciseTimeRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+	original := PreciseTime{Time{13, 26, 33}, 123_456_000}
+
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		buf, err := m.Encode(pgtype.TimeOID, format, original, nil)
+		assert.NoError(t, err)
+
+		var decoded PreciseTime
+		err = m.Scan(pgtype.TimeOID, format, buf, &decoded)
+		assert.NoError(t, err)
+		assert.Equal(t, original, decoded)
+
+		// Time truncates the fraction.
+		var truncated Time
+		err = m.Scan(pgtype.TimeOID, format, buf, &truncated)
+		assert.NoError(t, err)
+		assert.Equal(t, Time{13, 26, 33}, truncated)
+	}
+
+	assert.Equal(t, int64(48393_123_456), PreciseTime{Time{13, 26, 33}, 123_456_789}.microseconds())
+}
+
+func TestPreciseTimeText(t *testing.T) {
+	original := PreciseTime{Time{13, 26, 33}, 250_000_000}
+	text, err := original.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "13:26:33.25", string(text))
+
+	var decoded PreciseTime
+	assert.NoError(t, decoded.UnmarshalText(text))
+	assert.Equal(t, original, decoded)
+}
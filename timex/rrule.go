@@ -0,0 +1,539 @@
+package timex
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ of an RRule. Only date based frequencies are
+// supported.
+type Frequency int
+
+const (
+	Daily Frequency = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+func (f Frequency) String() string {
+	return frequencyNames[f]
+}
+
+// WeekdayNum is a BYDAY entry such as TU, 2TU or -1FR. N selects the n-th
+// occurrence of Weekday within the month or year, counting from the end if
+// negative; zero selects every occurrence.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+var weekdayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func (w WeekdayNum) String() string {
+	if w.N == 0 {
+		return weekdayCodes[w.Weekday]
+	}
+	return strconv.Itoa(w.N) + weekdayCodes[w.Weekday]
+}
+
+// ByDaysOfWeek returns a BYDAY list selecting every day set in dow.
+func ByDaysOfWeek(dow DaysOfWeek) []WeekdayNum {
+	var s []WeekdayNum
+	for _, weekday := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday} {
+		if dow.Has(weekday) {
+			s = append(s, WeekdayNum{Weekday: weekday})
+		}
+	}
+	return s
+}
+
+// RRule is a recurrence rule as defined by RFC 5545, section 3.3.10, limited
+// to whole days. The start date (DTSTART) is passed when evaluating the rule.
+type RRule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	Count      int
+	Until      Date
+}
+
+// ParseRRule parses the value of an RRULE property, e.g.
+// FREQ=MONTHLY;BYDAY=-1FR. A leading "RRULE:" is ignored.
+func ParseRRule(s string) (RRule, error) {
+	s = strings.TrimPrefix(s, "RRULE:")
+
+	var r RRule
+	for part := range strings.SplitSeq(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return RRule{}, fmt.Errorf("invalid rrule part %q", part)
+		}
+
+		var err error
+		switch name {
+		case "FREQ":
+			r.Freq, err = parseFrequency(value)
+		case "INTERVAL":
+			r.Interval, err = parsePositiveInt(value)
+		case "COUNT":
+			r.Count, err = parsePositiveInt(value)
+		case "UNTIL":
+			r.Until, err = parseICalDate(value)
+		case "BYDAY":
+			r.ByDay, err = parseList(value, parseWeekdayNum)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseList(value, parseMonthDay)
+		case "BYMONTH":
+			r.ByMonth, err = parseList(value, parseMonth)
+		case "WKST":
+			// Weeks always start on Monday, which is also the default.
+			if value != "MO" {
+				err = fmt.Errorf("unsupported WKST %q", value)
+			}
+		default:
+			err = fmt.Errorf("unsupported rrule part %q", name)
+		}
+		if err != nil {
+			return RRule{}, err
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return RRule{}, err
+	}
+	return r, nil
+}
+
+func (r RRule) validate() error {
+	if r.Freq == 0 {
+		return errors.New("rrule must contain FREQ")
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return errors.New("rrule must not contain both COUNT and UNTIL")
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return errors.New("BYMONTHDAY must not be used with FREQ=WEEKLY")
+	}
+	if r.Freq != Monthly && r.Freq != Yearly {
+		for _, w := range r.ByDay {
+			if w.N != 0 {
+				return fmt.Errorf("BYDAY=%s must not be used with FREQ=%s", w, r.Freq)
+			}
+		}
+	}
+	return nil
+}
+
+// String returns r in the RRULE value syntax, without the "RRULE:" prefix.
+func (r RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+formatICalDate(r.Until))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+formatList(r.ByMonth, func(m time.Month) string {
+			return strconv.Itoa(int(m))
+		}))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+formatList(r.ByMonthDay, strconv.Itoa))
+	}
+	if len(r.ByDay) > 0 {
+		parts = append(parts, "BYDAY="+formatList(r.ByDay, WeekdayNum.String))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (r RRule) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *RRule) UnmarshalText(data []byte) error {
+	var err error
+	*r, err = ParseRRule(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = RRule{}
+var _ encoding.TextUnmarshaler = &RRule{}
+
+// maxRecurrenceGapYears stops the evaluation of rules which no longer produce
+// any dates, e.g. FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30.
+const maxRecurrenceGapYears = 400
+
+// Dates returns an iterator over the occurrences of r starting at start that
+// fall within the given range. start is only an occurrence itself if it
+// matches the rule. The iterator never stops if neither r nor within limit
+// the number of occurrences. An invalid rule yields nothing.
+func (r RRule) Dates(start Date, within DateRange) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		if r.validate() != nil {
+			return
+		}
+
+		interval := max(r.Interval, 1)
+		last := start
+		var count int
+		for n := 0; ; n += interval {
+			periodStart, candidates := r.expand(start, n)
+			if (!r.Until.IsZero() && periodStart.After(r.Until)) ||
+				(within.hasEnd() && periodStart.After(within.End)) ||
+				periodStart.Year-last.Year > maxRecurrenceGapYears {
+				return
+			}
+
+			for _, d := range candidates {
+				if d.Before(start) {
+					continue
+				}
+				if (!r.Until.IsZero() && d.After(r.Until)) || (within.hasEnd() && d.After(within.End)) {
+					return
+				}
+				last = d
+				count++
+				if within.Contains(d) && !yield(d) {
+					return
+				}
+				if r.Count > 0 && count >= r.Count {
+					return
+				}
+			}
+		}
+	}
+}
+
+// LocalDateTimes is like Dates, but yields every occurrence at the time of
+// day of start.
+func (r RRule) LocalDateTimes(start LocalDateTime, within DateRange) iter.Seq[LocalDateTime] {
+	return func(yield func(LocalDateTime) bool) {
+		for d := range r.Dates(start.Date, within) {
+			if !yield(LocalDateTime{Date: d, Time: start.Time}) {
+				return
+			}
+		}
+	}
+}
+
+// expand returns the first day of the n-th period after the one containing
+// start and the dates of that period matching r, in ascending order.
+func (r RRule) expand(start Date, n int) (Date, []Date) {
+	switch r.Freq {
+	case Daily:
+		d := start.AddDays(n)
+		if r.matchesMonth(d) && r.matchesMonthDay(d) && r.matchesWeekday(d) {
+			return d, []Date{d}
+		}
+		return d, nil
+	case Weekly:
+		weekStart := start.StartOfWeek(time.Monday).AddDays(n * 7)
+		byDay := r.ByDay
+		if len(byDay) == 0 {
+			byDay = []WeekdayNum{{Weekday: start.Weekday()}}
+		}
+		var dates []Date
+		for d := range (DateRange{Start: weekStart, End: weekStart.AddDays(6)}).Days() {
+			if r.matchesMonth(d) && slices.ContainsFunc(byDay, func(w WeekdayNum) bool { return w.Weekday == d.Weekday() }) {
+				dates = append(dates, d)
+			}
+		}
+		return weekStart, dates
+	case Monthly:
+		m := start.YearMonth().AddMonths(n)
+		return m.FirstDay(), r.expandMonth(m, start)
+	case Yearly:
+		year := start.Year + n
+		return Date{year, time.January, 1}, r.expandYear(year, start)
+	default:
+		return start, nil
+	}
+}
+
+func (r RRule) expandMonth(m YearMonth, start Date) []Date {
+	if !r.matchesMonth(m.FirstDay()) {
+		return nil
+	}
+
+	switch {
+	case len(r.ByDay) > 0:
+		return slices.Collect(r.weekdaysIn(m.DateRange()))
+	case len(r.ByMonthDay) > 0:
+		var dates []Date
+		for d := range m.DateRange().Days() {
+			if r.matchesMonthDay(d) {
+				dates = append(dates, d)
+			}
+		}
+		return dates
+	case start.Day <= daysIn(m.Month, m.Year):
+		return []Date{{m.Year, m.Month, start.Day}}
+	default:
+		return nil
+	}
+}
+
+func (r RRule) expandYear(year int, start Date) []Date {
+	switch {
+	case len(r.ByDay) > 0 && len(r.ByMonth) == 0:
+		// Without BYMONTH, ordinals refer to the whole year.
+		period := DateRange{Start: Date{year, time.January, 1}, End: Date{year, time.December, 31}}
+		return slices.Collect(r.weekdaysIn(period))
+	case len(r.ByMonth) > 0 || len(r.ByMonthDay) > 0 || len(r.ByDay) > 0:
+		var dates []Date
+		for m := time.January; m <= time.December; m++ {
+			dates = append(dates, r.expandMonth(YearMonth{year, m}, start)...)
+		}
+		return dates
+	case start.Day <= daysIn(start.Month, year):
+		return []Date{{year, start.Month, start.Day}}
+	default:
+		return nil
+	}
+}
+
+// weekdaysIn returns the days in period matching both BYDAY, with ordinals
+// relative to period, and BYMONTHDAY.
+func (r RRule) weekdaysIn(period DateRange) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for d := range period.Days() {
+			if !r.matchesMonthDay(d) {
+				continue
+			}
+			for _, w := range r.ByDay {
+				if w.Weekday != d.Weekday() {
+					continue
+				}
+				if w.N == 0 ||
+					w.N > 0 && d.DaysSince(period.Start)/7+1 == w.N ||
+					w.N < 0 && period.End.DaysSince(d)/7+1 == -w.N {
+					if !yield(d) {
+						return
+					}
+					break
+				}
+			}
+		}
+	}
+}
+
+func (r RRule) matchesMonth(d Date) bool {
+	return len(r.ByMonth) == 0 || slices.Contains(r.ByMonth, d.Month)
+}
+
+func (r RRule) matchesMonthDay(d Date) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	last := daysIn(d.Month, d.Year)
+	return slices.ContainsFunc(r.ByMonthDay, func(md int) bool {
+		return md == d.Day || md < 0 && last+md+1 == d.Day
+	})
+}
+
+func (r RRule) matchesWeekday(d Date) bool {
+	return len(r.ByDay) == 0 || slices.ContainsFunc(r.ByDay, func(w WeekdayNum) bool {
+		return w.Weekday == d.Weekday()
+	})
+}
+
+// Recurrence is an RRule together with dates excluded from it (EXDATE).
+// Excluded dates still count towards the rule's COUNT.
+type Recurrence struct {
+	Rule    RRule
+	ExDates []Date
+}
+
+// ParseRecurrence parses an RRULE property optionally followed by EXDATE
+// properties, each on its own line, e.g.
+//
+//	RRULE:FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=24;UNTIL=20301224
+//	EXDATE;VALUE=DATE:20271224
+func ParseRecurrence(s string) (Recurrence, error) {
+	var rec Recurrence
+	var hasRule bool
+	for line := range strings.Lines(s) {
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "EXDATE"):
+			_, value, ok := strings.Cut(line, ":")
+			if !ok {
+				return Recurrence{}, fmt.Errorf("invalid EXDATE %q", line)
+			}
+			dates, err := parseList(value, parseICalDate)
+			if err != nil {
+				return Recurrence{}, err
+			}
+			rec.ExDates = append(rec.ExDates, dates...)
+		case !hasRule:
+			rule, err := ParseRRule(line)
+			if err != nil {
+				return Recurrence{}, err
+			}
+			rec.Rule, hasRule = rule, true
+		default:
+			return Recurrence{}, fmt.Errorf("unexpected line %q", line)
+		}
+	}
+	if !hasRule {
+		return Recurrence{}, errors.New("recurrence must contain an RRULE")
+	}
+	return rec, nil
+}
+
+func (rec Recurrence) String() string {
+	s := "RRULE:" + rec.Rule.String()
+	if len(rec.ExDates) > 0 {
+		s += "\nEXDATE;VALUE=DATE:" + formatList(rec.ExDates, formatICalDate)
+	}
+	return s
+}
+
+func (rec Recurrence) MarshalText() ([]byte, error) {
+	return []byte(rec.String()), nil
+}
+
+func (rec *Recurrence) UnmarshalText(data []byte) error {
+	var err error
+	*rec, err = ParseRecurrence(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = Recurrence{}
+var _ encoding.TextUnmarshaler = &Recurrence{}
+
+// Dates returns an iterator over the occurrences of rec starting at start that
+// fall within the given range, skipping excluded dates.
+func (rec Recurrence) Dates(start Date, within DateRange) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for d := range rec.Rule.Dates(start, within) {
+			if !slices.Contains(rec.ExDates, d) && !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// LocalDateTimes is like Dates, but yields every occurrence at the time of
+// day of start.
+func (rec Recurrence) LocalDateTimes(start LocalDateTime, within DateRange) iter.Seq[LocalDateTime] {
+	return func(yield func(LocalDateTime) bool) {
+		for d := range rec.Dates(start.Date, within) {
+			if !yield(LocalDateTime{Date: d, Time: start.Time}) {
+				return
+			}
+		}
+	}
+}
+
+func parseFrequency(s string) (Frequency, error) {
+	for f, name := range frequencyNames {
+		if name == s {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported FREQ %q", s)
+}
+
+func parsePositiveInt(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid positive integer %q", s)
+	}
+	return n, nil
+}
+
+func parseWeekdayNum(s string) (WeekdayNum, error) {
+	if len(s) < 2 {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+	}
+	weekday := slices.Index(weekdayCodes[:], s[len(s)-2:])
+	if weekday < 0 {
+		return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+	}
+
+	var n int
+	if ordinal := s[:len(s)-2]; ordinal != "" {
+		var err error
+		n, err = strconv.Atoi(ordinal)
+		if err != nil || n == 0 || n < -53 || n > 53 {
+			return WeekdayNum{}, fmt.Errorf("invalid BYDAY %q", s)
+		}
+	}
+	return WeekdayNum{N: n, Weekday: time.Weekday(weekday)}, nil
+}
+
+func parseMonthDay(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n == 0 || n < -31 || n > 31 {
+		return 0, fmt.Errorf("invalid BYMONTHDAY %q", s)
+	}
+	return n, nil
+}
+
+func parseMonth(s string) (time.Month, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > 12 {
+		return 0, fmt.Errorf("invalid BYMONTH %q", s)
+	}
+	return time.Month(n), nil
+}
+
+// parseICalDate parses a DATE (20250101) or the date part of a DATE-TIME
+// (20250101T120000Z) value.
+func parseICalDate(s string) (Date, error) {
+	if len(s) > 8 && s[8] == 'T' {
+		s = s[:8]
+	}
+	t, err := time.Parse("20060102", s)
+	if err != nil {
+		return Date{}, fmt.Errorf("invalid date %q", s)
+	}
+	return NewDateFromTime(t), nil
+}
+
+func formatICalDate(d Date) string {
+	return fmt.Sprintf("%04d%02d%02d", d.Year, d.Month, d.Day)
+}
+
+func parseList[T any](s string, parse func(string) (T, error)) ([]T, error) {
+	var list []T
+	for item := range strings.SplitSeq(s, ",") {
+		v, err := parse(item)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+func formatList[T any](list []T, format func(T) string) string {
+	s := make([]string, len(list))
+	for i, v := range list {
+		s[i] = format(v)
+	}
+	return strings.Join(s, ",")
+}
@@ -0,0 +1,284 @@
+package timex
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    RRule
+		wantErr bool
+	}{
+		{
+			str:  "FREQ=DAILY",
+			want: RRule{Freq: Daily},
+		},
+		{
+			str:  "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TU",
+			want: RRule{Freq: Weekly, Interval: 2, ByDay: []WeekdayNum{{Weekday: time.Tuesday}}},
+		},
+		{
+			str:  "FREQ=MONTHLY;BYDAY=-1FR,1MO;COUNT=10",
+			want: RRule{Freq: Monthly, Count: 10, ByDay: []WeekdayNum{{-1, time.Friday}, {1, time.Monday}}},
+		},
+		{
+			str:  "FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=24;UNTIL=20301224",
+			want: RRule{Freq: Yearly, ByMonth: []time.Month{time.December}, ByMonthDay: []int{24}, Until: Date{2030, 12, 24}},
+		},
+		{
+			str:  "FREQ=YEARLY;UNTIL=20301224T235959Z;WKST=MO",
+			want: RRule{Freq: Yearly, Until: Date{2030, 12, 24}},
+		},
+		{str: "", wantErr: true},
+		{str: "INTERVAL=2", wantErr: true},
+		{str: "FREQ=HOURLY", wantErr: true},
+		{str: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{str: "FREQ=DAILY;COUNT=-1", wantErr: true},
+		{str: "FREQ=DAILY;COUNT=2;UNTIL=20301224", wantErr: true},
+		{str: "FREQ=DAILY;BYDAY=1MO", wantErr: true},
+		{str: "FREQ=WEEKLY;BYMONTHDAY=1", wantErr: true},
+		{str: "FREQ=MONTHLY;BYDAY=XX", wantErr: true},
+		{str: "FREQ=MONTHLY;BYDAY=0MO", wantErr: true},
+		{str: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{str: "FREQ=YEARLY;BYMONTH=13", wantErr: true},
+		{str: "FREQ=YEARLY;BYSETPOS=1", wantErr: true},
+		{str: "FREQ=WEEKLY;WKST=SU", wantErr: true},
+		{str: "FREQ=WEEKLY;", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRRule(tt.str)
+		if tt.wantErr {
+			assert.Error(t, err, tt.str)
+			continue
+		}
+		assert.NoError(t, err, tt.str)
+		assert.Equal(t, tt.want, got, tt.str)
+	}
+}
+
+func TestRRuleStringRoundTrip(t *testing.T) {
+	tests := []string{
+		"FREQ=DAILY",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU",
+		"FREQ=WEEKLY;BYDAY=MO,WE,FR",
+		"FREQ=MONTHLY;COUNT=10;BYDAY=-1FR",
+		"FREQ=MONTHLY;BYMONTHDAY=1,-1",
+		"FREQ=YEARLY;UNTIL=20301224;BYMONTH=12;BYMONTHDAY=24",
+		"FREQ=YEARLY;BYMONTH=5;BYDAY=-1MO",
+	}
+
+	for _, s := range tests {
+		r, err := ParseRRule(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, s, r.String())
+
+		text, err := json.Marshal(r)
+		assert.NoError(t, err)
+		var decoded RRule
+		assert.NoError(t, json.Unmarshal(text, &decoded))
+		assert.Equal(t, r, decoded)
+	}
+}
+
+func TestRRuleDates(t *testing.T) {
+	all := DateRange{StartBound: Unbounded, EndBound: Unbounded}
+
+	tests := []struct {
+		desc   string
+		rule   string
+		start  Date
+		within DateRange
+		want   []Date
+	}{
+		{
+			desc:   "every 3 days",
+			rule:   "FREQ=DAILY;INTERVAL=3;COUNT=4",
+			start:  Date{2025, 12, 29},
+			within: all,
+			want:   []Date{{2025, 12, 29}, {2026, 1, 1}, {2026, 1, 4}, {2026, 1, 7}},
+		},
+		{
+			desc:   "every second tuesday",
+			rule:   "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU;COUNT=4",
+			start:  Date{2025, 7, 1},
+			within: all,
+			want:   []Date{{2025, 7, 1}, {2025, 7, 15}, {2025, 7, 29}, {2025, 8, 12}},
+		},
+		{
+			desc:   "weekly defaults to the start's weekday",
+			rule:   "FREQ=WEEKLY;COUNT=3",
+			start:  Date{2025, 7, 3},
+			within: all,
+			want:   []Date{{2025, 7, 3}, {2025, 7, 10}, {2025, 7, 17}},
+		},
+		{
+			desc:   "weekly on several days starting midweek",
+			rule:   "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=5",
+			start:  Date{2025, 7, 2},
+			within: all,
+			want:   []Date{{2025, 7, 2}, {2025, 7, 4}, {2025, 7, 7}, {2025, 7, 9}, {2025, 7, 11}},
+		},
+		{
+			desc:   "last friday of the month",
+			rule:   "FREQ=MONTHLY;BYDAY=-1FR;COUNT=4",
+			start:  Date{2025, 1, 1},
+			within: all,
+			want:   []Date{{2025, 1, 31}, {2025, 2, 28}, {2025, 3, 28}, {2025, 4, 25}},
+		},
+		{
+			desc:   "first and last day of the month",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=5",
+			start:  Date{2024, 1, 15},
+			within: all,
+			want:   []Date{{2024, 1, 31}, {2024, 2, 1}, {2024, 2, 29}, {2024, 3, 1}, {2024, 3, 31}},
+		},
+		{
+			desc:   "monthly skips short months",
+			rule:   "FREQ=MONTHLY;COUNT=3",
+			start:  Date{2025, 1, 31},
+			within: all,
+			want:   []Date{{2025, 1, 31}, {2025, 3, 31}, {2025, 5, 31}},
+		},
+		{
+			desc:   "friday the 13th",
+			rule:   "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13;COUNT=3",
+			start:  Date{2025, 1, 1},
+			within: all,
+			want:   []Date{{2025, 6, 13}, {2026, 2, 13}, {2026, 3, 13}},
+		},
+		{
+			desc:   "yearly on 24 dec until 2030",
+			rule:   "FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=24;UNTIL=20301224",
+			start:  Date{2025, 1, 1},
+			within: all,
+			want:   []Date{{2025, 12, 24}, {2026, 12, 24}, {2027, 12, 24}, {2028, 12, 24}, {2029, 12, 24}, {2030, 12, 24}},
+		},
+		{
+			desc:   "yearly defaults to the start's month and day",
+			rule:   "FREQ=YEARLY;COUNT=3",
+			start:  Date{2024, 2, 29},
+			within: all,
+			want:   []Date{{2024, 2, 29}, {2028, 2, 29}, {2032, 2, 29}},
+		},
+		{
+			desc:   "last monday of may",
+			rule:   "FREQ=YEARLY;BYMONTH=5;BYDAY=-1MO;COUNT=2",
+			start:  Date{2025, 1, 1},
+			within: all,
+			want:   []Date{{2025, 5, 26}, {2026, 5, 25}},
+		},
+		{
+			desc:   "20th monday of the year",
+			rule:   "FREQ=YEARLY;BYDAY=20MO;COUNT=3",
+			start:  Date{1997, 5, 19},
+			within: all,
+			want:   []Date{{1997, 5, 19}, {1998, 5, 18}, {1999, 5, 17}},
+		},
+		{
+			desc:   "bounded by a range",
+			rule:   "FREQ=WEEKLY;BYDAY=SA",
+			start:  Date{2025, 1, 1},
+			within: DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 20}},
+			want:   []Date{{2025, 7, 5}, {2025, 7, 12}, {2025, 7, 19}},
+		},
+		{
+			desc:   "count applies before the range",
+			rule:   "FREQ=DAILY;COUNT=10",
+			start:  Date{2025, 7, 1},
+			within: DateRange{Start: Date{2025, 7, 8}, EndBound: Unbounded},
+			want:   []Date{{2025, 7, 8}, {2025, 7, 9}, {2025, 7, 10}},
+		},
+		{
+			desc:   "never matching",
+			rule:   "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			start:  Date{2025, 1, 1},
+			within: all,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseRRule(tt.rule)
+		assert.NoError(t, err, tt.desc)
+		assert.Equal(t, tt.want, slices.Collect(r.Dates(tt.start, tt.within)), tt.desc)
+	}
+}
+
+func TestRRuleDatesUnlimited(t *testing.T) {
+	r := RRule{Freq: Weekly, ByDay: ByDaysOfWeek(DaysOfWeek{Sa: true, Su: true})}
+
+	var got []Date
+	for d := range r.Dates(Date{2025, 7, 1}, DateRange{StartBound: Unbounded, EndBound: Unbounded}) {
+		got = append(got, d)
+		if len(got) == 4 {
+			break
+		}
+	}
+	assert.Equal(t, []Date{{2025, 7, 5}, {2025, 7, 6}, {2025, 7, 12}, {2025, 7, 13}}, got)
+
+	assert.Empty(t, slices.Collect(RRule{}.Dates(Date{2025, 7, 1}, DateRange{StartBound: Unbounded, EndBound: Unbounded})))
+}
+
+func TestByDaysOfWeek(t *testing.T) {
+	assert.Equal(t, []WeekdayNum{
+		{Weekday: time.Monday},
+		{Weekday: time.Friday},
+		{Weekday: time.Sunday},
+	}, ByDaysOfWeek(DaysOfWeek{Mo: true, Fr: true, Su: true}))
+	assert.Empty(t, ByDaysOfWeek(DaysOfWeek{}))
+}
+
+func TestRRuleLocalDateTimes(t *testing.T) {
+	r := RRule{Freq: Daily, Count: 2}
+	start := LocalDateTime{Date{2025, 7, 1}, Time{Hour: 15}}
+
+	assert.Equal(t, []LocalDateTime{
+		{Date{2025, 7, 1}, Time{Hour: 15}},
+		{Date{2025, 7, 2}, Time{Hour: 15}},
+	}, slices.Collect(r.LocalDateTimes(start, DateRange{StartBound: Unbounded, EndBound: Unbounded})))
+}
+
+func TestParseRecurrence(t *testing.T) {
+	rec, err := ParseRecurrence("RRULE:FREQ=YEARLY;UNTIL=20301224;BYMONTH=12;BYMONTHDAY=24\r\nEXDATE;VALUE=DATE:20271224,20281224\r\nEXDATE:20291224T000000Z\r\n")
+	assert.NoError(t, err)
+	assert.Equal(t, Recurrence{
+		Rule: RRule{
+			Freq:       Yearly,
+			ByMonth:    []time.Month{time.December},
+			ByMonthDay: []int{24},
+			Until:      Date{2030, 12, 24},
+		},
+		ExDates: []Date{{2027, 12, 24}, {2028, 12, 24}, {2029, 12, 24}},
+	}, rec)
+
+	assert.Equal(t, []Date{{2025, 12, 24}, {2026, 12, 24}, {2030, 12, 24}},
+		slices.Collect(rec.Dates(Date{2025, 1, 1}, DateRange{StartBound: Unbounded, EndBound: Unbounded})))
+
+	assert.Equal(t, "RRULE:FREQ=YEARLY;UNTIL=20301224;BYMONTH=12;BYMONTHDAY=24\nEXDATE;VALUE=DATE:20271224,20281224,20291224", rec.String())
+
+	roundTripped, err := ParseRecurrence(rec.String())
+	assert.NoError(t, err)
+	assert.Equal(t, rec, roundTripped)
+
+	for _, s := range []string{"", "EXDATE:20271224", "RRULE:FREQ=DAILY\nRRULE:FREQ=WEEKLY", "RRULE:FREQ=DAILY\nEXDATE:2027-12-24"} {
+		_, err := ParseRecurrence(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestRecurrenceCountIncludesExDates(t *testing.T) {
+	rec := Recurrence{
+		Rule:    RRule{Freq: Daily, Count: 3},
+		ExDates: []Date{{2025, 7, 2}},
+	}
+
+	assert.Equal(t, []Date{{2025, 7, 1}, {2025, 7, 3}},
+		slices.Collect(rec.Dates(Date{2025, 7, 1}, DateRange{StartBound: Unbounded, EndBound: Unbounded})))
+}
@@ -0,0 +1,394 @@
+package timex
+
+import (
+	"encoding"
+	"fmt"
+	"iter"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WeeklySchedule describes recurring weekly hours, e.g. opening hours of
+// Mo-Fr 08:00-12:00,14:00-18:00; Sa 09:00-12:00.
+type WeeklySchedule struct {
+	// Rules assign hours to days of the week. If several rules cover the
+	// same day, the last one wins, so a rule without hours closes the days it
+	// covers.
+	Rules []ScheduleRule
+	// Exceptions replace the hours of specific dates, e.g. closures or
+	// special hours on public holidays. A date mapped to no hours is closed.
+	Exceptions map[Date][]TimeRange
+}
+
+type ScheduleRule struct {
+	Days  DaysOfWeek
+	Hours []TimeRange
+}
+
+// OpenInterval is a period during which a WeeklySchedule is open, from Start
+// up to, but not including, End.
+type OpenInterval struct {
+	Start LocalDateTime
+	End   LocalDateTime
+}
+
+// HoursOn returns the hours of d, taking exceptions into account.
+func (s WeeklySchedule) HoursOn(d Date) []TimeRange {
+	if hours, ok := s.Exceptions[d]; ok {
+		return hours
+	}
+	for _, rule := range slices.Backward(s.Rules) {
+		if rule.Days.Has(d.Weekday()) {
+			return rule.Hours
+		}
+	}
+	return nil
+}
+
+// IsOpenAt reports whether s is open at dt. Hours crossing midnight count
+// towards the day they start on.
+func (s WeeklySchedule) IsOpenAt(dt LocalDateTime) bool {
+	for _, d := range []Date{dt.Date.AddDays(-1), dt.Date} {
+		for _, r := range s.HoursOn(d) {
+			start := LocalDateTime{Date: d, Time: r.Start}
+			if !dt.Before(start) && dt.Before(start.Add(r.Duration())) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NextOpening returns the start of the first opening at or after dt. If s is
+// already open at dt, that is the opening after the current one. The boolean
+// result is false if s never opens again.
+func (s WeeklySchedule) NextOpening(dt LocalDateTime) (LocalDateTime, bool) {
+	for i := range s.intervals(dt.Date.AddDays(-1), s.horizon(dt.Date)) {
+		if !i.Start.Before(dt) {
+			return i.Start, true
+		}
+	}
+	return LocalDateTime{}, false
+}
+
+// NextClosing returns the end of the current opening if s is open at dt, and
+// the end of the next opening otherwise. The boolean result is false if s
+// never closes, e.g. for 24/7, or never opens again.
+func (s WeeklySchedule) NextClosing(dt LocalDateTime) (LocalDateTime, bool) {
+	horizon := s.horizon(dt.Date)
+	for i := range s.intervals(dt.Date.AddDays(-1), horizon) {
+		// An opening lasting until past the horizon never ends.
+		if i.End.After(LocalDateTime{Date: horizon}) {
+			break
+		}
+		if i.End.After(dt) {
+			return i.End, true
+		}
+	}
+	return LocalDateTime{}, false
+}
+
+// OpenIntervals returns an iterator over the periods s is open within r in
+// ascending order. Adjacent hours are merged and intervals are cut off at the
+// bounds of r. It yields nothing if r has an open start and never stops if r
+// has an open end. In the latter case an opening without end, as in a 24/7
+// schedule, is yielded in consecutive pieces ending at midnight, each longer
+// than a week.
+func (s WeeklySchedule) OpenIntervals(r DateRange) iter.Seq[OpenInterval] {
+	return func(yield func(OpenInterval) bool) {
+		if !r.hasStart() {
+			return
+		}
+
+		start := LocalDateTime{Date: r.Start}
+		until := Date{}
+		var end LocalDateTime
+		if r.hasEnd() {
+			until = r.End
+			end = LocalDateTime{Date: r.End.AddDays(1)}
+		}
+
+		for i := range s.intervals(r.Start.AddDays(-1), until) {
+			if r.hasEnd() && !i.Start.Before(end) {
+				return
+			}
+			if !i.End.After(start) {
+				continue
+			}
+			if i.Start.Before(start) {
+				i.Start = start
+			}
+			if r.hasEnd() && i.End.After(end) {
+				i.End = end
+			}
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// intervals returns an iterator over the merged openings starting on the days
+// from through until. A zero until never stops, so an opening still going on
+// past its horizon is cut at midnight to not withhold it forever.
+func (s WeeklySchedule) intervals(from, until Date) iter.Seq[OpenInterval] {
+	return func(yield func(OpenInterval) bool) {
+		var pending OpenInterval
+		var hasPending bool
+
+		for d := from; until.IsZero() || !d.After(until); d = d.AddDays(1) {
+			midnight := LocalDateTime{Date: d}
+			if until.IsZero() && hasPending && !pending.End.Before(midnight) && d.After(s.horizon(pending.Start.Date)) {
+				if !yield(OpenInterval{Start: pending.Start, End: midnight}) {
+					return
+				}
+				pending.Start = midnight
+				hasPending = pending.End.After(midnight)
+			}
+
+			hours := slices.SortedFunc(slices.Values(s.HoursOn(d)), func(a, b TimeRange) int {
+				return a.Start.Compare(b.Start)
+			})
+			for _, r := range hours {
+				start := LocalDateTime{Date: d, Time: r.Start}
+				i := OpenInterval{Start: start, End: start.Add(r.Duration())}
+				if hasPending && !i.Start.After(pending.End) {
+					if i.End.After(pending.End) {
+						pending.End = i.End
+					}
+					continue
+				}
+				if hasPending && !yield(pending) {
+					return
+				}
+				pending, hasPending = i, true
+			}
+		}
+		if hasPending {
+			yield(pending)
+		}
+	}
+}
+
+// horizon returns the last day worth searching for openings after d. Beyond
+// the last exception the schedule repeats weekly, so one more week suffices.
+func (s WeeklySchedule) horizon(d Date) Date {
+	last := d
+	for exception := range s.Exceptions {
+		if exception.After(last) {
+			last = exception
+		}
+	}
+	return last.AddDays(8)
+}
+
+var osmWeekdays = [7]string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"}
+
+var osmMonths = [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+
+// ParseOpeningHours parses a subset of the OpenStreetMap opening_hours syntax:
+// rules separated by semicolons, each consisting of an optional selector of
+// weekdays (Mo-Fr,Sa) or a single date (2025 Dec 24), followed by
+// comma-separated hours (08:00-12:00,14:00-18:00) or off. 24/7 is accepted
+// as a rule on its own. A rule without selector applies to every day.
+func ParseOpeningHours(str string) (WeeklySchedule, error) {
+	var s WeeklySchedule
+	for rule := range strings.SplitSeq(str, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		if rule == "24/7" {
+			s.Rules = append(s.Rules, ScheduleRule{
+				Days:  EveryDay(),
+				Hours: []TimeRange{{}},
+			})
+			continue
+		}
+
+		fields := strings.Fields(commaSpace.ReplaceAllString(rule, ","))
+		hours, err := parseOSMHours(fields[len(fields)-1])
+		if err != nil {
+			return WeeklySchedule{}, err
+		}
+
+		selector := fields[:len(fields)-1]
+		switch len(selector) {
+		case 0:
+			s.Rules = append(s.Rules, ScheduleRule{
+				Days:  EveryDay(),
+				Hours: hours,
+			})
+		case 1:
+			days, err := parseOSMWeekdays(selector[0])
+			if err != nil {
+				return WeeklySchedule{}, err
+			}
+			s.Rules = append(s.Rules, ScheduleRule{Days: days, Hours: hours})
+		case 3:
+			d, err := parseOSMDate(selector)
+			if err != nil {
+				return WeeklySchedule{}, err
+			}
+			if s.Exceptions == nil {
+				s.Exceptions = make(map[Date][]TimeRange)
+			}
+			s.Exceptions[d] = hours
+		default:
+			return WeeklySchedule{}, fmt.Errorf("unsupported opening hours rule %q", rule)
+		}
+	}
+	return s, nil
+}
+
+// commaSpace matches whitespace around commas, which is removed so that
+// Mo, Tu 08:00-12:00, 14:00-18:00 splits like Mo,Tu 08:00-12:00,14:00-18:00.
+var commaSpace = regexp.MustCompile(`\s*,\s*`)
+
+func parseOSMWeekdays(s string) (DaysOfWeek, error) {
+	var days [7]bool
+	for part := range strings.SplitSeq(s, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		from := slices.Index(osmWeekdays[:], first)
+		to := from
+		if isRange {
+			to = slices.Index(osmWeekdays[:], last)
+		}
+		if from < 0 || to < 0 {
+			return DaysOfWeek{}, fmt.Errorf("invalid weekdays %q", s)
+		}
+		for i := from; ; i = (i + 1) % 7 {
+			days[i] = true
+			if i == to {
+				break
+			}
+		}
+	}
+	parsed, err := ParseDaysOfWeek(days[:])
+	if err != nil {
+		return DaysOfWeek{}, err
+	}
+	return *parsed, nil
+}
+
+func parseOSMDate(fields []string) (Date, error) {
+	year, err := strconv.Atoi(fields[0])
+	month := slices.Index(osmMonths[:], fields[1])
+	day, err2 := strconv.Atoi(fields[2])
+	if err != nil || err2 != nil || month < 0 || len(fields[0]) != 4 {
+		return Date{}, fmt.Errorf("invalid date %q", strings.Join(fields, " "))
+	}
+
+	d := Date{year, time.Month(month + 1), day}
+	if day < 1 || day > daysIn(d.Month, year) {
+		return Date{}, fmt.Errorf("invalid date %q", strings.Join(fields, " "))
+	}
+	return d, nil
+}
+
+func parseOSMHours(s string) ([]TimeRange, error) {
+	if s == "off" || s == "closed" {
+		return nil, nil
+	}
+
+	var hours []TimeRange
+	for part := range strings.SplitSeq(s, ",") {
+		start, end, ok := strings.Cut(part, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid hours %q", s)
+		}
+		var r TimeRange
+		var err error
+		if r.Start, err = ParseTime(start); err != nil {
+			return nil, err
+		}
+		// OSM writes the end of the day as 24:00, which ParseTime rejects.
+		if end != "24:00" {
+			if r.End, err = ParseTime(end); err != nil {
+				return nil, err
+			}
+		}
+		hours = append(hours, r)
+	}
+	return hours, nil
+}
+
+// String returns s in the OpenStreetMap opening_hours syntax accepted by
+// ParseOpeningHours. Exceptions follow the weekly rules sorted by date.
+func (s WeeklySchedule) String() string {
+	if len(s.Exceptions) == 0 && len(s.Rules) == 1 &&
+		s.Rules[0].Days == EveryDay() &&
+		slices.Equal(s.Rules[0].Hours, []TimeRange{{}}) {
+		return "24/7"
+	}
+
+	var rules []string
+	for _, rule := range s.Rules {
+		if rule.Days == (DaysOfWeek{}) {
+			continue
+		}
+		rules = append(rules, formatOSMWeekdays(rule.Days)+" "+formatOSMHours(rule.Hours))
+	}
+	for _, d := range slices.SortedFunc(maps.Keys(s.Exceptions), func(a, b Date) int { return a.Compare(b) }) {
+		date := fmt.Sprintf("%04d %s %02d", d.Year, osmMonths[d.Month-1], d.Day)
+		rules = append(rules, date+" "+formatOSMHours(s.Exceptions[d]))
+	}
+	return strings.Join(rules, "; ")
+}
+
+func formatOSMWeekdays(days DaysOfWeek) string {
+	has := days.DaysOfWeek()
+	var parts []string
+	for i := 0; i < 7; i++ {
+		if !has[i] {
+			continue
+		}
+		j := i
+		for j+1 < 7 && has[j+1] {
+			j++
+		}
+		switch {
+		case j == i:
+			parts = append(parts, osmWeekdays[i])
+		case j == i+1:
+			parts = append(parts, osmWeekdays[i], osmWeekdays[j])
+		default:
+			parts = append(parts, osmWeekdays[i]+"-"+osmWeekdays[j])
+		}
+		i = j
+	}
+	return strings.Join(parts, ",")
+}
+
+func formatOSMHours(hours []TimeRange) string {
+	if len(hours) == 0 {
+		return "off"
+	}
+
+	parts := make([]string, len(hours))
+	for i, r := range hours {
+		end := formatRangeTime(r.End)
+		if r.End.IsZero() {
+			end = "24:00"
+		}
+		parts[i] = formatRangeTime(r.Start) + "-" + end
+	}
+	return strings.Join(parts, ",")
+}
+
+func (s WeeklySchedule) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *WeeklySchedule) UnmarshalText(data []byte) error {
+	var err error
+	*s, err = ParseOpeningHours(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = WeeklySchedule{}
+var _ encoding.TextUnmarshaler = &WeeklySchedule{}
@@ -0,0 +1,261 @@
+package timex
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var officeHours = WeeklySchedule{
+	Rules: []ScheduleRule{
+		{
+			Days: DaysOfWeek{Mo: true, Tu: true, We: true, Th: true, Fr: true},
+			Hours: []TimeRange{
+				{Time{Hour: 8}, Time{Hour: 12}},
+				{Time{Hour: 14}, Time{Hour: 18}},
+			},
+		},
+		{
+			Days:  DaysOfWeek{Sa: true},
+			Hours: []TimeRange{{Time{Hour: 9}, Time{Hour: 12}}},
+		},
+	},
+	Exceptions: map[Date][]TimeRange{
+		{2025, 12, 24}: {{Time{Hour: 9}, Time{Hour: 12}}},
+		{2025, 12, 25}: nil,
+	},
+}
+
+var barHours = WeeklySchedule{
+	Rules: []ScheduleRule{
+		{
+			Days:  DaysOfWeek{Th: true, Fr: true, Sa: true},
+			Hours: []TimeRange{{Time{Hour: 18}, Time{Hour: 2}}},
+		},
+		{
+			Days: DaysOfWeek{Th: true},
+			Hours: []TimeRange{
+				{Time{Hour: 18}, Time{Hour: 23}},
+			},
+		},
+	},
+}
+
+func TestWeeklyScheduleIsOpenAt(t *testing.T) {
+	tests := []struct {
+		desc     string
+		schedule WeeklySchedule
+		dt       LocalDateTime
+		want     bool
+	}{
+		{"tuesday morning", officeHours, LocalDateTime{Date{2025, 7, 1}, Time{Hour: 9}}, true},
+		{"tuesday lunch break", officeHours, LocalDateTime{Date{2025, 7, 1}, Time{Hour: 12}}, false},
+		{"tuesday afternoon", officeHours, LocalDateTime{Date{2025, 7, 1}, Time{Hour: 17, Minute: 59}}, true},
+		{"tuesday evening", officeHours, LocalDateTime{Date{2025, 7, 1}, Time{Hour: 18}}, false},
+		{"saturday", officeHours, LocalDateTime{Date{2025, 7, 5}, Time{Hour: 10}}, true},
+		{"sunday", officeHours, LocalDateTime{Date{2025, 7, 6}, Time{Hour: 10}}, false},
+		{"christmas eve morning", officeHours, LocalDateTime{Date{2025, 12, 24}, Time{Hour: 10}}, true},
+		{"christmas eve afternoon", officeHours, LocalDateTime{Date{2025, 12, 24}, Time{Hour: 15}}, false},
+		{"christmas", officeHours, LocalDateTime{Date{2025, 12, 25}, Time{Hour: 10}}, false},
+		{"friday night", barHours, LocalDateTime{Date{2025, 7, 4}, Time{Hour: 23}}, true},
+		{"after midnight", barHours, LocalDateTime{Date{2025, 7, 5}, Time{Hour: 1}}, true},
+		{"closing", barHours, LocalDateTime{Date{2025, 7, 5}, Time{Hour: 2}}, false},
+		{"thursday overridden", barHours, LocalDateTime{Date{2025, 7, 3}, Time{Hour: 23, Minute: 30}}, false},
+		{"after sunday midnight", barHours, LocalDateTime{Date{2025, 7, 6}, Time{Hour: 1}}, true},
+		{"monday after midnight", barHours, LocalDateTime{Date{2025, 7, 7}, Time{Hour: 1}}, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.schedule.IsOpenAt(tt.dt), tt.desc)
+	}
+}
+
+func TestWeeklyScheduleNextOpeningAndClosing(t *testing.T) {
+	tests := []struct {
+		desc        string
+		schedule    WeeklySchedule
+		dt          LocalDateTime
+		wantOpening LocalDateTime
+		wantClosing LocalDateTime
+	}{
+		{
+			desc:        "before opening",
+			schedule:    officeHours,
+			dt:          LocalDateTime{Date{2025, 7, 1}, Time{Hour: 7}},
+			wantOpening: LocalDateTime{Date{2025, 7, 1}, Time{Hour: 8}},
+			wantClosing: LocalDateTime{Date{2025, 7, 1}, Time{Hour: 12}},
+		},
+		{
+			desc:        "while open",
+			schedule:    officeHours,
+			dt:          LocalDateTime{Date{2025, 7, 1}, Time{Hour: 10}},
+			wantOpening: LocalDateTime{Date{2025, 7, 1}, Time{Hour: 14}},
+			wantClosing: LocalDateTime{Date{2025, 7, 1}, Time{Hour: 12}},
+		},
+		{
+			desc:        "saturday afternoon",
+			schedule:    officeHours,
+			dt:          LocalDateTime{Date{2025, 7, 5}, Time{Hour: 13}},
+			wantOpening: LocalDateTime{Date{2025, 7, 7}, Time{Hour: 8}},
+			wantClosing: LocalDateTime{Date{2025, 7, 7}, Time{Hour: 12}},
+		},
+		{
+			desc:        "over christmas",
+			schedule:    officeHours,
+			dt:          LocalDateTime{Date{2025, 12, 24}, Time{Hour: 13}},
+			wantOpening: LocalDateTime{Date{2025, 12, 26}, Time{Hour: 8}},
+			wantClosing: LocalDateTime{Date{2025, 12, 26}, Time{Hour: 12}},
+		},
+		{
+			desc:        "across midnight",
+			schedule:    barHours,
+			dt:          LocalDateTime{Date{2025, 7, 4}, Time{Hour: 23}},
+			wantOpening: LocalDateTime{Date{2025, 7, 5}, Time{Hour: 18}},
+			wantClosing: LocalDateTime{Date{2025, 7, 5}, Time{Hour: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		opening, ok := tt.schedule.NextOpening(tt.dt)
+		assert.True(t, ok, tt.desc)
+		assert.Equal(t, tt.wantOpening, opening, tt.desc)
+
+		closing, ok := tt.schedule.NextClosing(tt.dt)
+		assert.True(t, ok, tt.desc)
+		assert.Equal(t, tt.wantClosing, closing, tt.desc)
+	}
+
+	allDay, err := ParseOpeningHours("24/7")
+	assert.NoError(t, err)
+	_, ok := allDay.NextOpening(LocalDateTime{Date{2025, 7, 1}, Time{Hour: 10}})
+	assert.False(t, ok)
+	_, ok = allDay.NextClosing(LocalDateTime{Date{2025, 7, 1}, Time{Hour: 10}})
+	assert.False(t, ok)
+
+	_, ok = WeeklySchedule{}.NextOpening(LocalDateTime{Date{2025, 7, 1}, Time{Hour: 10}})
+	assert.False(t, ok)
+}
+
+func TestWeeklyScheduleOpenIntervals(t *testing.T) {
+	got := slices.Collect(officeHours.OpenIntervals(DateRange{Start: Date{2025, 12, 24}, End: Date{2025, 12, 27}}))
+	assert.Equal(t, []OpenInterval{
+		{LocalDateTime{Date{2025, 12, 24}, Time{Hour: 9}}, LocalDateTime{Date{2025, 12, 24}, Time{Hour: 12}}},
+		{LocalDateTime{Date{2025, 12, 26}, Time{Hour: 8}}, LocalDateTime{Date{2025, 12, 26}, Time{Hour: 12}}},
+		{LocalDateTime{Date{2025, 12, 26}, Time{Hour: 14}}, LocalDateTime{Date{2025, 12, 26}, Time{Hour: 18}}},
+		{LocalDateTime{Date{2025, 12, 27}, Time{Hour: 9}}, LocalDateTime{Date{2025, 12, 27}, Time{Hour: 12}}},
+	}, got)
+
+	got = slices.Collect(barHours.OpenIntervals(DateRange{Start: Date{2025, 7, 6}, End: Date{2025, 7, 6}}))
+	assert.Equal(t, []OpenInterval{
+		{LocalDateTime{Date{2025, 7, 6}, Time{}}, LocalDateTime{Date{2025, 7, 6}, Time{Hour: 2}}},
+	}, got)
+
+	allDay, err := ParseOpeningHours("24/7")
+	assert.NoError(t, err)
+	got = slices.Collect(allDay.OpenIntervals(DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 3}}))
+	assert.Equal(t, []OpenInterval{
+		{LocalDateTime{Date{2025, 7, 1}, Time{}}, LocalDateTime{Date{2025, 7, 4}, Time{}}},
+	}, got)
+}
+
+func TestWeeklyScheduleOpenIntervalsUnbounded(t *testing.T) {
+	s := WeeklySchedule{Rules: []ScheduleRule{{Days: EveryDay(), Hours: []TimeRange{{Time{}, Time{}}}}}}
+
+	var got []OpenInterval
+	for i := range s.OpenIntervals(DateRange{Start: Date{2025, 1, 1}, EndBound: Unbounded}) {
+		got = append(got, i)
+		if len(got) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []OpenInterval{
+		{LocalDateTime{Date{2025, 1, 1}, Time{}}, LocalDateTime{Date{2025, 1, 9}, Time{}}},
+		{LocalDateTime{Date{2025, 1, 9}, Time{}}, LocalDateTime{Date{2025, 1, 18}, Time{}}},
+	}, got)
+
+	// Regular openings are unaffected.
+	got = nil
+	for i := range officeHours.OpenIntervals(DateRange{Start: Date{2025, 12, 26}, EndBound: Unbounded}) {
+		got = append(got, i)
+		if len(got) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []OpenInterval{
+		{LocalDateTime{Date{2025, 12, 26}, Time{Hour: 8}}, LocalDateTime{Date{2025, 12, 26}, Time{Hour: 12}}},
+		{LocalDateTime{Date{2025, 12, 26}, Time{Hour: 14}}, LocalDateTime{Date{2025, 12, 26}, Time{Hour: 18}}},
+	}, got)
+}
+
+func TestParseOpeningHours(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    WeeklySchedule
+		wantStr string
+		wantErr bool
+	}{
+		{
+			str:     "Mo-Fr 08:00-12:00,14:00-18:00; Sa 09:00-12:00; 2025 Dec 24 09:00-12:00; 2025 Dec 25 off",
+			want:    officeHours,
+			wantStr: "Mo-Fr 08:00-12:00,14:00-18:00; Sa 09:00-12:00; 2025 Dec 24 09:00-12:00; 2025 Dec 25 off",
+		},
+		{
+			str: "24/7",
+			want: WeeklySchedule{Rules: []ScheduleRule{
+				{Days: EveryDay(), Hours: []TimeRange{{}}},
+			}},
+			wantStr: "24/7",
+		},
+		{
+			str: "Mo-Su 00:00-24:00",
+			want: WeeklySchedule{Rules: []ScheduleRule{
+				{Days: EveryDay(), Hours: []TimeRange{{}}},
+			}},
+			wantStr: "24/7",
+		},
+		{
+			str: "10:00-18:00; Sa-Mo,We 18:00-02:00; Tu off",
+			want: WeeklySchedule{Rules: []ScheduleRule{
+				{Days: EveryDay(), Hours: []TimeRange{{Time{Hour: 10}, Time{Hour: 18}}}},
+				{Days: DaysOfWeek{Mo: true, We: true, Sa: true, Su: true}, Hours: []TimeRange{{Time{Hour: 18}, Time{Hour: 2}}}},
+				{Days: DaysOfWeek{Tu: true}},
+			}},
+			wantStr: "Mo-Su 10:00-18:00; Mo,We,Sa,Su 18:00-02:00; Tu off",
+		},
+		{
+			str: "Mo,Tu 18:00-24:00",
+			want: WeeklySchedule{Rules: []ScheduleRule{
+				{Days: DaysOfWeek{Mo: true, Tu: true}, Hours: []TimeRange{{Time{Hour: 18}, Time{}}}},
+			}},
+			wantStr: "Mo,Tu 18:00-24:00",
+		},
+		{
+			str: "Mo, Tu 08:00-12:00, 14:00-18:00;  Sa 09:00-12:00 ",
+			want: WeeklySchedule{Rules: []ScheduleRule{
+				{Days: DaysOfWeek{Mo: true, Tu: true}, Hours: []TimeRange{{Time{Hour: 8}, Time{Hour: 12}}, {Time{Hour: 14}, Time{Hour: 18}}}},
+				{Days: DaysOfWeek{Sa: true}, Hours: []TimeRange{{Time{Hour: 9}, Time{Hour: 12}}}},
+			}},
+			wantStr: "Mo,Tu 08:00-12:00,14:00-18:00; Sa 09:00-12:00",
+		},
+		{str: "", want: WeeklySchedule{}, wantStr: ""},
+		{str: "Mo-Xy 10:00-12:00", wantErr: true},
+		{str: "Mo 10:00", wantErr: true},
+		{str: "Mo 10:00-25:00", wantErr: true},
+		{str: "2025 Feb 30 off", wantErr: true},
+		{str: "25 Feb 03 off", wantErr: true},
+		{str: "PH off", wantErr: true},
+		{str: "Mo 10:00-12:00 open", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseOpeningHours(tt.str)
+		if tt.wantErr {
+			assert.Error(t, err, tt.str)
+			continue
+		}
+		assert.NoError(t, err, tt.str)
+		assert.Equal(t, tt.want, got, tt.str)
+		assert.Equal(t, tt.wantStr, got.String(), tt.str)
+	}
+}
@@ -0,0 +1,257 @@
+package timex
+
+import (
+	"cmp"
+	"encoding"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MonthDay is a day of the year without the year, e.g. 15 July.
+type MonthDay struct {
+	Month time.Month
+	Day   int
+}
+
+// In returns md in year. 29 February becomes 28 February in common years.
+func (md MonthDay) In(year int) Date {
+	return Date{year, md.Month, min(md.Day, daysIn(md.Month, year))}
+}
+
+func (md MonthDay) Before(md2 MonthDay) bool {
+	if md.Month != md2.Month {
+		return md.Month < md2.Month
+	}
+	return md.Day < md2.Day
+}
+
+func (md MonthDay) After(md2 MonthDay) bool {
+	return md2.Before(md)
+}
+
+func (md MonthDay) Compare(md2 MonthDay) int {
+	if md.Before(md2) {
+		return -1
+	} else if md.After(md2) {
+		return +1
+	}
+	return 0
+}
+
+// ParseMonthDay parses a month and day in the form 07-15 or --07-15 as in ISO
+// 8601.
+func ParseMonthDay(s string) (MonthDay, error) {
+	// Parse in a leap year so 02-29 is accepted.
+	d, err := ParseDate("2000-" + strings.TrimPrefix(s, "--"))
+	if err != nil {
+		return MonthDay{}, fmt.Errorf("invalid month and day %q", s)
+	}
+	return MonthDay{d.Month, d.Day}, nil
+}
+
+// String returns md in the ISO 8601 form --07-15.
+func (md MonthDay) String() string {
+	return fmt.Sprintf("--%02d-%02d", md.Month, md.Day)
+}
+
+func (md MonthDay) MarshalText() ([]byte, error) {
+	return []byte(md.String()), nil
+}
+
+func (md *MonthDay) UnmarshalText(data []byte) error {
+	var err error
+	*md, err = ParseMonthDay(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = MonthDay{}
+var _ encoding.TextUnmarshaler = &MonthDay{}
+
+// SeasonPeriod is a yearly recurring period from Start through End, both
+// inclusive. If End is before Start the period crosses the year boundary, e.g.
+// 20 December through 6 January.
+type SeasonPeriod struct {
+	Start MonthDay
+	End   MonthDay
+}
+
+// CrossesYear reports whether p ends in the year after it starts.
+func (p SeasonPeriod) CrossesYear() bool {
+	return p.End.Before(p.Start)
+}
+
+// In returns the occurrence of p starting in year.
+func (p SeasonPeriod) In(year int) DateRange {
+	end := year
+	if p.CrossesYear() {
+		end++
+	}
+	return DateRange{Start: p.Start.In(year), End: p.End.In(end)}
+}
+
+// Season is a named set of yearly recurring periods, e.g. a high season from
+// 15 July through 31 August.
+type Season struct {
+	Name    string
+	Periods []SeasonPeriod
+	// Days limits the season to certain days of the week, e.g. weekend rates.
+	// An empty Days applies to every day.
+	Days DaysOfWeek
+}
+
+// Materialize returns the periods of s starting in year sorted by start.
+// Periods crossing the year boundary end in the following year. Days is not
+// taken into account.
+func (s Season) Materialize(year int) []DateRange {
+	ranges := make([]DateRange, len(s.Periods))
+	for i, p := range s.Periods {
+		ranges[i] = p.In(year)
+	}
+	slices.SortFunc(ranges, compareStarts)
+	return ranges
+}
+
+// Contains reports whether d falls into one of the periods of s on one of its
+// days of the week.
+func (s Season) Contains(d Date) bool {
+	if !s.Days.IsEmpty() && !s.Days.Has(d.Weekday()) {
+		return false
+	}
+	for _, p := range s.Periods {
+		if p.In(d.Year).Contains(d) || p.In(d.Year-1).Contains(d) {
+			return true
+		}
+	}
+	return false
+}
+
+// SeasonCalendar resolves the season of a date. If seasons overlap, the first
+// one containing a date wins, so more specific seasons such as holidays
+// should come before general ones.
+type SeasonCalendar struct {
+	Seasons []Season
+}
+
+func NewSeasonCalendar(seasons ...Season) SeasonCalendar {
+	return SeasonCalendar{Seasons: seasons}
+}
+
+// Resolve returns the index of the season d falls into. The boolean result is
+// false if d is not covered by any season.
+func (c SeasonCalendar) Resolve(d Date) (int, bool) {
+	for i, s := range c.Seasons {
+		if s.Contains(d) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+var errUnboundedSeasonRange = errors.New("cannot resolve seasons in an unbounded date range")
+
+// SeasonSegment is a part of a DateRange falling into the season at Index, or
+// into no season if Index is -1.
+type SeasonSegment struct {
+	Range DateRange
+	Index int
+}
+
+// Split divides r into consecutive segments by the season each day resolves
+// to. The segments cover every day of r in order. It returns an error if r is
+// not bounded on both sides.
+func (c SeasonCalendar) Split(r DateRange) ([]SeasonSegment, error) {
+	if !r.hasStart() || !r.hasEnd() {
+		return nil, errUnboundedSeasonRange
+	}
+
+	var segments []SeasonSegment
+	for d := range r.Days() {
+		index, _ := c.Resolve(d)
+		if n := len(segments); n > 0 && segments[n-1].Index == index {
+			segments[n-1].Range.End = d
+			continue
+		}
+		segments = append(segments, SeasonSegment{
+			Range: DateRange{Start: d, End: d},
+			Index: index,
+		})
+	}
+	return segments, nil
+}
+
+// Gaps returns the runs of days in r not covered by any season. It returns an
+// error if r is not bounded on both sides.
+func (c SeasonCalendar) Gaps(r DateRange) ([]DateRange, error) {
+	segments, err := c.Split(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var gaps []DateRange
+	for _, segment := range segments {
+		if segment.Index < 0 {
+			gaps = append(gaps, segment.Range)
+		}
+	}
+	return gaps, nil
+}
+
+// SeasonOverlap is a run of days covered by both the seasons at First and
+// Second, with First < Second.
+type SeasonOverlap struct {
+	Range  DateRange
+	First  int
+	Second int
+}
+
+// Overlaps returns the runs of days in r covered by more than one season,
+// sorted by start and season indexes. It returns an error if r is not bounded
+// on both sides.
+func (c SeasonCalendar) Overlaps(r DateRange) ([]SeasonOverlap, error) {
+	if !r.hasStart() || !r.hasEnd() {
+		return nil, errUnboundedSeasonRange
+	}
+
+	var overlaps []SeasonOverlap
+	// open maps a pair of season indexes to its current run in overlaps.
+	open := make(map[[2]int]int)
+	for d := range r.Days() {
+		var matches []int
+		for i, s := range c.Seasons {
+			if s.Contains(d) {
+				matches = append(matches, i)
+			}
+		}
+
+		seen := make(map[[2]int]bool)
+		for i, first := range matches {
+			for _, second := range matches[i+1:] {
+				pair := [2]int{first, second}
+				seen[pair] = true
+				if j, ok := open[pair]; ok {
+					overlaps[j].Range.End = d
+					continue
+				}
+				open[pair] = len(overlaps)
+				overlaps = append(overlaps, SeasonOverlap{
+					Range:  DateRange{Start: d, End: d},
+					First:  first,
+					Second: second,
+				})
+			}
+		}
+		for pair := range open {
+			if !seen[pair] {
+				delete(open, pair)
+			}
+		}
+	}
+
+	slices.SortStableFunc(overlaps, func(a, b SeasonOverlap) int {
+		return cmp.Or(a.Range.Start.Compare(b.Range.Start), cmp.Compare(a.First, b.First), cmp.Compare(a.Second, b.Second))
+	})
+	return overlaps, nil
+}
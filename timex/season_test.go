@@ -0,0 +1,173 @@
+package timex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	highSeason = Season{
+		Name:    "high",
+		Periods: []SeasonPeriod{{MonthDay{time.July, 15}, MonthDay{time.August, 31}}},
+	}
+	christmas = Season{
+		Name:    "christmas",
+		Periods: []SeasonPeriod{{MonthDay{time.December, 20}, MonthDay{time.January, 6}}},
+	}
+	lowSeason = Season{
+		Name: "low",
+		Periods: []SeasonPeriod{
+			{MonthDay{time.September, 1}, MonthDay{time.December, 19}},
+			{MonthDay{time.January, 7}, MonthDay{time.July, 14}},
+		},
+	}
+	summerWeekends = Season{
+		Name:    "summer weekends",
+		Periods: []SeasonPeriod{{MonthDay{time.July, 1}, MonthDay{time.August, 31}}},
+		Days:    Weekend(),
+	}
+)
+
+func TestParseMonthDay(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    MonthDay
+		wantErr bool
+	}{
+		{str: "07-15", want: MonthDay{time.July, 15}},
+		{str: "--12-20", want: MonthDay{time.December, 20}},
+		{str: "02-29", want: MonthDay{time.February, 29}},
+		{str: "02-30", wantErr: true},
+		{str: "13-01", wantErr: true},
+		{str: "2025-07-15", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMonthDay(tt.str)
+		if tt.wantErr {
+			assert.Error(t, err, tt.str)
+			continue
+		}
+		assert.NoError(t, err, tt.str)
+		assert.Equal(t, tt.want, got, tt.str)
+	}
+
+	assert.Equal(t, "--07-15", MonthDay{time.July, 15}.String())
+	assert.Equal(t, Date{2025, 2, 28}, MonthDay{time.February, 29}.In(2025))
+	assert.Equal(t, Date{2024, 2, 29}, MonthDay{time.February, 29}.In(2024))
+}
+
+func TestSeasonMaterialize(t *testing.T) {
+	assert.Equal(t, []DateRange{{Start: Date{2025, 7, 15}, End: Date{2025, 8, 31}}}, highSeason.Materialize(2025))
+	assert.Equal(t, []DateRange{{Start: Date{2025, 12, 20}, End: Date{2026, 1, 6}}}, christmas.Materialize(2025))
+	assert.Equal(t, []DateRange{
+		{Start: Date{2025, 1, 7}, End: Date{2025, 7, 14}},
+		{Start: Date{2025, 9, 1}, End: Date{2025, 12, 19}},
+	}, lowSeason.Materialize(2025))
+}
+
+func TestSeasonContains(t *testing.T) {
+	tests := []struct {
+		season Season
+		date   Date
+		want   bool
+	}{
+		{highSeason, Date{2025, 7, 15}, true},
+		{highSeason, Date{2025, 7, 14}, false},
+		{christmas, Date{2025, 12, 31}, true},
+		{christmas, Date{2026, 1, 6}, true},
+		{christmas, Date{2026, 1, 7}, false},
+		{christmas, Date{2025, 12, 19}, false},
+		{summerWeekends, Date{2025, 7, 5}, true},
+		{summerWeekends, Date{2025, 7, 7}, false},
+		{summerWeekends, Date{2025, 9, 6}, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.season.Contains(tt.date), "%s %s", tt.season.Name, tt.date)
+	}
+}
+
+func TestSeasonCalendarResolve(t *testing.T) {
+	c := NewSeasonCalendar(christmas, highSeason, lowSeason)
+
+	tests := []struct {
+		date Date
+		want int
+		ok   bool
+	}{
+		{Date{2025, 1, 1}, 0, true},
+		{Date{2025, 1, 7}, 2, true},
+		{Date{2025, 8, 1}, 1, true},
+		{Date{2025, 12, 19}, 2, true},
+		{Date{2025, 12, 20}, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := c.Resolve(tt.date)
+		assert.Equal(t, tt.ok, ok, tt.date.String())
+		assert.Equal(t, tt.want, got, tt.date.String())
+	}
+
+	_, ok := NewSeasonCalendar(highSeason).Resolve(Date{2025, 1, 1})
+	assert.False(t, ok)
+}
+
+func TestSeasonCalendarSplit(t *testing.T) {
+	c := NewSeasonCalendar(summerWeekends, highSeason)
+
+	got, err := c.Split(DateRange{Start: Date{2025, 7, 10}, End: Date{2025, 7, 21}})
+	assert.NoError(t, err)
+	assert.Equal(t, []SeasonSegment{
+		{DateRange{Start: Date{2025, 7, 10}, End: Date{2025, 7, 11}}, -1},
+		{DateRange{Start: Date{2025, 7, 12}, End: Date{2025, 7, 13}}, 0},
+		{DateRange{Start: Date{2025, 7, 14}, End: Date{2025, 7, 14}}, -1},
+		{DateRange{Start: Date{2025, 7, 15}, End: Date{2025, 7, 18}}, 1},
+		{DateRange{Start: Date{2025, 7, 19}, End: Date{2025, 7, 20}}, 0},
+		{DateRange{Start: Date{2025, 7, 21}, End: Date{2025, 7, 21}}, 1},
+	}, got)
+
+	unbounded := DateRange{Start: Date{2025, 7, 10}, EndBound: Unbounded}
+	_, err = c.Split(unbounded)
+	assert.Error(t, err)
+	_, err = c.Gaps(unbounded)
+	assert.Error(t, err)
+	_, err = c.Overlaps(unbounded)
+	assert.Error(t, err)
+}
+
+func TestSeasonCalendarGapsAndOverlaps(t *testing.T) {
+	year := DateRange{Start: Date{2025, 1, 1}, End: Date{2025, 12, 31}}
+
+	complete := NewSeasonCalendar(christmas, highSeason, lowSeason)
+	gaps, err := complete.Gaps(year)
+	assert.NoError(t, err)
+	assert.Empty(t, gaps)
+	overlaps, err := complete.Overlaps(year)
+	assert.NoError(t, err)
+	assert.Empty(t, overlaps)
+
+	withHoles := NewSeasonCalendar(highSeason, christmas)
+	gaps, err = withHoles.Gaps(year)
+	assert.NoError(t, err)
+	assert.Equal(t, []DateRange{
+		{Start: Date{2025, 1, 7}, End: Date{2025, 7, 14}},
+		{Start: Date{2025, 9, 1}, End: Date{2025, 12, 19}},
+	}, gaps)
+
+	overlapping := NewSeasonCalendar(highSeason, summerWeekends, Season{
+		Name:    "august",
+		Periods: []SeasonPeriod{{MonthDay{time.August, 1}, MonthDay{time.August, 31}}},
+	})
+	overlaps, err = overlapping.Overlaps(DateRange{Start: Date{2025, 7, 15}, End: Date{2025, 8, 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, []SeasonOverlap{
+		{DateRange{Start: Date{2025, 7, 19}, End: Date{2025, 7, 20}}, 0, 1},
+		{DateRange{Start: Date{2025, 7, 26}, End: Date{2025, 7, 27}}, 0, 1},
+		{DateRange{Start: Date{2025, 8, 1}, End: Date{2025, 8, 3}}, 0, 2},
+		{DateRange{Start: Date{2025, 8, 2}, End: Date{2025, 8, 3}}, 0, 1},
+		{DateRange{Start: Date{2025, 8, 2}, End: Date{2025, 8, 3}}, 1, 2},
+	}, overlaps)
+}
@@ -0,0 +1,216 @@
+package timex
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Stay is a hotel stay from the day of arrival to the day of departure. The
+// guest occupies the nights from Arrival up to, but not including, Departure,
+// so arriving on 2025-07-01 and departing on 2025-07-05 is a stay of four
+// nights.
+//
+// In Postgres a Stay maps to a daterange in the half-open form
+// [arrival,departure).
+type Stay struct {
+	Arrival   Date
+	Departure Date
+}
+
+// NewStayFromNights returns the stay occupying the nights in r. The boolean
+// result is false if r is not bounded on both sides.
+func NewStayFromNights(r DateRange) (Stay, bool) {
+	if !r.hasStart() || !r.hasEnd() {
+		return Stay{}, false
+	}
+	return Stay{Arrival: r.Start, Departure: r.End.AddDays(1)}, true
+}
+
+// Nights returns the number of nights of s. It is zero if s is empty.
+func (s Stay) Nights() int {
+	return max(s.Departure.DaysSince(s.Arrival), 0)
+}
+
+// IsEmpty reports whether s has no nights, i.e. Departure is not after
+// Arrival.
+func (s Stay) IsEmpty() bool {
+	return !s.Departure.After(s.Arrival)
+}
+
+// EachNight returns an iterator over the nights of s, each identified by the
+// date the night starts on.
+func (s Stay) EachNight() iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for d := s.Arrival; d.Before(s.Departure); d = d.AddDays(1) {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// HasNight reports whether the night starting on d is part of s.
+func (s Stay) HasNight(d Date) bool {
+	return !d.Before(s.Arrival) && d.Before(s.Departure)
+}
+
+// DateRange returns the nights of s as an inclusive DateRange, i.e. from
+// Arrival to the day before Departure.
+func (s Stay) DateRange() DateRange {
+	return DateRange{Start: s.Arrival, End: s.Departure.AddDays(-1)}
+}
+
+// StaySegment is a part of a Stay falling into the season at Index, or into
+// no season if Index is -1.
+type StaySegment struct {
+	Stay  Stay
+	Index int
+}
+
+// Split divides s into consecutive segments by the seasons the nights fall
+// into, e.g. to price each night with the rate of its season. If seasons
+// overlap, the first one containing a night wins. The segments cover every
+// night of s in order.
+func (s Stay) Split(seasons []DateRange) []StaySegment {
+	var segments []StaySegment
+	for night := range s.EachNight() {
+		index := -1
+		for i, season := range seasons {
+			if season.Contains(night) {
+				index = i
+				break
+			}
+		}
+
+		if n := len(segments); n > 0 && segments[n-1].Index == index {
+			segments[n-1].Stay.Departure = night.AddDays(1)
+			continue
+		}
+		segments = append(segments, StaySegment{
+			Stay:  Stay{Arrival: night, Departure: night.AddDays(1)},
+			Index: index,
+		})
+	}
+	return segments
+}
+
+// String returns s as an ISO 8601 time interval, e.g. 2025-07-01/2025-07-05.
+func (s Stay) String() string {
+	return s.Arrival.String() + "/" + s.Departure.String()
+}
+
+// ParseStay parses a stay in the form returned by String.
+func ParseStay(str string) (Stay, error) {
+	arrival, departure, ok := strings.Cut(str, "/")
+	if !ok {
+		return Stay{}, fmt.Errorf("invalid stay %q", str)
+	}
+
+	var s Stay
+	var err error
+	if s.Arrival, err = ParseDate(arrival); err != nil {
+		return Stay{}, err
+	}
+	if s.Departure, err = ParseDate(departure); err != nil {
+		return Stay{}, err
+	}
+	return s, nil
+}
+
+func (s Stay) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Stay) UnmarshalText(data []byte) error {
+	var err error
+	*s, err = ParseStay(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = Stay{}
+var _ encoding.TextUnmarshaler = &Stay{}
+
+type stayJSON struct {
+	Arrival   Date `json:"arrival"`
+	Departure Date `json:"departure"`
+}
+
+func (s Stay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(stayJSON(s))
+}
+
+func (s *Stay) UnmarshalJSON(data []byte) error {
+	var v stayJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*s = Stay(v)
+	return nil
+}
+
+var _ json.Marshaler = Stay{}
+var _ json.Unmarshaler = &Stay{}
+
+func (s *Stay) ScanNull() error {
+	return fmt.Errorf("cannot scan NULL into daterange")
+}
+
+func (s *Stay) ScanBounds() (lowerTarget, upperTarget any) {
+	*s = Stay{}
+	return &stayBound{&s.Arrival}, &stayBound{&s.Departure}
+}
+
+func (s *Stay) SetBoundTypes(lower, upper pgtype.BoundType) error {
+	if lower == pgtype.Empty {
+		*s = Stay{}
+		return nil
+	}
+	if lower == pgtype.Unbounded || upper == pgtype.Unbounded {
+		return errors.New("cannot scan unbounded daterange into Stay")
+	}
+
+	if lower == pgtype.Exclusive {
+		s.Arrival = s.Arrival.AddDays(1)
+	}
+	if upper == pgtype.Inclusive {
+		s.Departure = s.Departure.AddDays(1)
+	}
+	return nil
+}
+
+func (s Stay) IsNull() bool {
+	return false
+}
+
+func (s Stay) BoundTypes() (lower, upper pgtype.BoundType) {
+	if s.IsEmpty() {
+		return pgtype.Empty, pgtype.Empty
+	}
+	return pgtype.Inclusive, pgtype.Exclusive
+}
+
+func (s Stay) Bounds() (lower, upper any) {
+	return s.Arrival, s.Departure
+}
+
+// stayBound scans a range element into a Date. Unlike dateBound it rejects
+// infinity, which a Stay cannot represent.
+type stayBound struct {
+	date *Date
+}
+
+func (b *stayBound) ScanDate(v pgtype.Date) error {
+	if v.InfinityModifier != pgtype.Finite {
+		return errors.New("cannot scan infinite daterange into Stay")
+	}
+	return b.date.ScanDate(v)
+}
+
+var _ pgtype.RangeScanner = &Stay{}
+var _ pgtype.RangeValuer = Stay{}
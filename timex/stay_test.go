@@ -0,0 +1,153 @@
+package timex
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStayNights(t *testing.T) {
+	s := Stay{Arrival: Date{2025, 7, 1}, Departure: Date{2025, 7, 5}}
+
+	assert.Equal(t, 4, s.Nights())
+	assert.False(t, s.IsEmpty())
+	assert.Equal(t, []Date{{2025, 7, 1}, {2025, 7, 2}, {2025, 7, 3}, {2025, 7, 4}}, slices.Collect(s.EachNight()))
+	assert.True(t, s.HasNight(Date{2025, 7, 1}))
+	assert.True(t, s.HasNight(Date{2025, 7, 4}))
+	assert.False(t, s.HasNight(Date{2025, 7, 5}))
+	assert.Equal(t, DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 4}}, s.DateRange())
+
+	fromNights, ok := NewStayFromNights(s.DateRange())
+	assert.True(t, ok)
+	assert.Equal(t, s, fromNights)
+	_, ok = NewStayFromNights(DateRange{Start: Date{2025, 7, 1}, EndBound: Unbounded})
+	assert.False(t, ok)
+	_, ok = NewStayFromNights(DateRange{StartBound: Infinite, End: Date{2025, 7, 4}})
+	assert.False(t, ok)
+
+	dayUse := Stay{Arrival: Date{2025, 7, 1}, Departure: Date{2025, 7, 1}}
+	assert.Equal(t, 0, dayUse.Nights())
+	assert.True(t, dayUse.IsEmpty())
+	assert.Empty(t, slices.Collect(dayUse.EachNight()))
+
+	invalid := Stay{Arrival: Date{2025, 7, 5}, Departure: Date{2025, 7, 1}}
+	assert.Equal(t, 0, invalid.Nights())
+	assert.True(t, invalid.IsEmpty())
+}
+
+func TestStaySplit(t *testing.T) {
+	seasons := []DateRange{
+		{Start: Date{2025, 6, 1}, End: Date{2025, 7, 2}},
+		{Start: Date{2025, 7, 3}, End: Date{2025, 8, 31}},
+		{Start: Date{2025, 7, 1}, End: Date{2025, 7, 31}},
+	}
+
+	tests := []struct {
+		desc string
+		stay Stay
+		want []StaySegment
+	}{
+		{
+			desc: "across two seasons",
+			stay: Stay{Arrival: Date{2025, 7, 1}, Departure: Date{2025, 7, 5}},
+			want: []StaySegment{
+				{Stay{Date{2025, 7, 1}, Date{2025, 7, 3}}, 0},
+				{Stay{Date{2025, 7, 3}, Date{2025, 7, 5}}, 1},
+			},
+		},
+		{
+			desc: "within a season",
+			stay: Stay{Arrival: Date{2025, 7, 10}, Departure: Date{2025, 7, 12}},
+			want: []StaySegment{
+				{Stay{Date{2025, 7, 10}, Date{2025, 7, 12}}, 1},
+			},
+		},
+		{
+			desc: "departure on the first day of the next season",
+			stay: Stay{Arrival: Date{2025, 6, 30}, Departure: Date{2025, 7, 3}},
+			want: []StaySegment{
+				{Stay{Date{2025, 6, 30}, Date{2025, 7, 3}}, 0},
+			},
+		},
+		{
+			desc: "partly outside of any season",
+			stay: Stay{Arrival: Date{2025, 8, 30}, Departure: Date{2025, 9, 2}},
+			want: []StaySegment{
+				{Stay{Date{2025, 8, 30}, Date{2025, 9, 1}}, 1},
+				{Stay{Date{2025, 9, 1}, Date{2025, 9, 2}}, -1},
+			},
+		},
+		{
+			desc: "empty stay",
+			stay: Stay{Arrival: Date{2025, 7, 1}, Departure: Date{2025, 7, 1}},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.stay.Split(seasons), tt.desc)
+	}
+}
+
+func TestStayMarshal(t *testing.T) {
+	s := Stay{Arrival: Date{2025, 7, 1}, Departure: Date{2025, 7, 5}}
+
+	assert.Equal(t, "2025-07-01/2025-07-05", s.String())
+	parsed, err := ParseStay("2025-07-01/2025-07-05")
+	assert.NoError(t, err)
+	assert.Equal(t, s, parsed)
+
+	for _, str := range []string{"2025-07-01", "2025-07-01/", "x/2025-07-05"} {
+		_, err := ParseStay(str)
+		assert.Error(t, err, str)
+	}
+
+	got, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"arrival":"2025-07-01","departure":"2025-07-05"}`, string(got))
+
+	var decoded Stay
+	assert.NoError(t, json.Unmarshal(got, &decoded))
+	assert.Equal(t, s, decoded)
+}
+
+func TestStayRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+
+	for _, original := range []Stay{
+		{Arrival: Date{2025, 7, 1}, Departure: Date{2025, 7, 5}},
+		{},
+	} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			buf, err := m.Encode(pgtype.DaterangeOID, format, original, nil)
+			assert.NoError(t, err)
+
+			var decoded Stay
+			err = m.Scan(pgtype.DaterangeOID, format, buf, &decoded)
+			assert.NoError(t, err)
+			assert.Equal(t, original, decoded)
+		}
+	}
+
+	buf, err := m.Encode(pgtype.DaterangeOID, pgtype.TextFormatCode, Stay{Arrival: Date{2025, 7, 1}, Departure: Date{2025, 7, 5}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "[2025-07-01,2025-07-05)", string(buf))
+
+	var decoded Stay
+	assert.NoError(t, m.Scan(pgtype.DaterangeOID, pgtype.TextFormatCode, []byte("[2025-07-01,2025-07-04]"), &decoded))
+	assert.Equal(t, Stay{Arrival: Date{2025, 7, 1}, Departure: Date{2025, 7, 5}}, decoded)
+	for _, src := range []string{"[2025-07-01,)", "[2025-07-01,infinity)", "[-infinity,2025-07-05)"} {
+		assert.Error(t, m.Scan(pgtype.DaterangeOID, pgtype.TextFormatCode, []byte(src), &decoded), src)
+	}
+	for _, original := range []DateRange{
+		{Start: Date{2025, 7, 1}, EndBound: Infinite},
+		{StartBound: Infinite, End: Date{2025, 7, 5}},
+	} {
+		buf, err := m.Encode(pgtype.DaterangeOID, pgtype.BinaryFormatCode, original, nil)
+		assert.NoError(t, err)
+		assert.Error(t, m.Scan(pgtype.DaterangeOID, pgtype.BinaryFormatCode, buf, &decoded), original.String())
+	}
+}
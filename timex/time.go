@@ -20,8 +20,10 @@ func NewTime(t time.Time) Time {
 	return tm
 }
 
+// ParseTime parses a time of day in the form 15:04 or 15:04:05. Fractional
+// seconds are accepted but truncated; use ParsePreciseTime to keep them.
 func ParseTime(s string) (Time, error) {
-	t, err := time.Parse(time.TimeOnly, s)
+	t, err := parseClock(s)
 	if err != nil {
 		return Time{}, err
 	}
@@ -59,6 +61,20 @@ func (t Time) Compare(t2 Time) int {
 	return 0
 }
 
+// Add returns t+d on a 24 hour clock, wrapping around midnight in either
+// direction, e.g. 23:00 plus three hours is 02:00. Fractions of a second in d
+// are truncated.
+func (t Time) Add(d time.Duration) Time {
+	d = (t.sinceMidnight() + d%Day + Day) % Day
+	return newTimeFromDuration(d)
+}
+
+// Sub returns the duration t-t2 within the same day. The result is negative
+// if t is before t2.
+func (t Time) Sub(t2 Time) time.Duration {
+	return t.sinceMidnight() - t2.sinceMidnight()
+}
+
 func (t Time) MarshalText() ([]byte, error) {
 	return []byte(t.String()), nil
 }
@@ -101,6 +117,30 @@ func newTimeFromMicroseconds(usec int64) Time {
 	}
 }
 
+func newTimeFromDuration(d time.Duration) Time {
+	return Time{
+		Hour:   int(d / time.Hour),
+		Minute: int(d % time.Hour / time.Minute),
+		Second: int(d % time.Minute / time.Second),
+	}
+}
+
+func (t Time) sinceMidnight() time.Duration {
+	return time.Duration(t.Hour)*time.Hour +
+		time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second
+}
+
+// parseClock parses a time of day in the form 15:04, 15:04:05 or
+// 15:04:05.999999999.
+func parseClock(s string) (time.Time, error) {
+	layout := time.TimeOnly
+	if len(s) == len("15:04") {
+		layout = "15:04"
+	}
+	return time.Parse(layout, s)
+}
+
 func (t Time) microseconds() int64 {
 	return int64((t.Hour*3600 + t.Minute*60 + t.Second) * 1_000_000)
 }
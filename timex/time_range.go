@@ -0,0 +1,184 @@
+package timex
+
+import (
+	"encoding"
+	"fmt"
+	"iter"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TimeRange is a daily time window from Start up to, but not including, End,
+// e.g. opening hours or a check-in window. If End is not after Start the
+// window crosses midnight, so 22:00-02:00 lasts four hours. If Start equals
+// End the window spans the whole day.
+//
+// In Postgres, a TimeRange is stored as a range of time, e.g. a custom type
+// created with CREATE TYPE timerange AS RANGE (subtype = time). A range ending
+// at midnight gets an upper bound of 24:00. Postgres ranges cannot wrap
+// around, so encoding a range crossing midnight, including a whole day not
+// starting at midnight, fails.
+type TimeRange struct {
+	Start Time
+	End   Time
+}
+
+// Duration returns the length of r, which is between zero (exclusive) and 24
+// hours (inclusive).
+func (r TimeRange) Duration() time.Duration {
+	d := r.End.Sub(r.Start)
+	if d <= 0 {
+		d += Day
+	}
+	return d
+}
+
+// CrossesMidnight reports whether r continues into the next day. A range
+// ending at exactly midnight, such as 22:00-00:00, does not.
+func (r TimeRange) CrossesMidnight() bool {
+	return r.Start.sinceMidnight()+r.Duration() > Day
+}
+
+// Contains reports whether t falls into r.
+func (r TimeRange) Contains(t Time) bool {
+	return (t.Sub(r.Start)+Day)%Day < r.Duration()
+}
+
+// Overlaps reports whether r and r2 have at least one point in time in
+// common.
+func (r TimeRange) Overlaps(r2 TimeRange) bool {
+	start, end := r.Start.sinceMidnight(), r.Start.sinceMidnight()+r.Duration()
+	for _, shift := range []time.Duration{-Day, 0, Day} {
+		start2 := r2.Start.sinceMidnight() + shift
+		if start2 < end && start < start2+r2.Duration() {
+			return true
+		}
+	}
+	return false
+}
+
+// Slots returns an iterator over the times in r at a fixed interval, starting
+// at r.Start, e.g. 12:00, 12:15, …, 14:15 for 12:00-14:30 and 15 minutes. It
+// yields nothing if interval is not positive.
+func (r TimeRange) Slots(interval time.Duration) iter.Seq[Time] {
+	return func(yield func(Time) bool) {
+		if interval <= 0 {
+			return
+		}
+		for d := time.Duration(0); d < r.Duration(); d += interval {
+			if !yield(r.Start.Add(d)) {
+				return
+			}
+		}
+	}
+}
+
+// ParseTimeRange parses a range in the form 12:00-14:30. Both times are
+// parsed by ParseTime.
+func ParseTimeRange(s string) (TimeRange, error) {
+	start, end, ok := strings.Cut(s, "-")
+	if !ok {
+		return TimeRange{}, fmt.Errorf("invalid time range %q", s)
+	}
+
+	var r TimeRange
+	var err error
+	if r.Start, err = ParseTime(start); err != nil {
+		return TimeRange{}, err
+	}
+	if r.End, err = ParseTime(end); err != nil {
+		return TimeRange{}, err
+	}
+	return r, nil
+}
+
+// String returns r in the form 12:00-14:30. Seconds are only included if
+// either time has any.
+func (r TimeRange) String() string {
+	return formatRangeTime(r.Start) + "-" + formatRangeTime(r.End)
+}
+
+func formatRangeTime(t Time) string {
+	if t.Second == 0 {
+		return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+	}
+	return t.String()
+}
+
+func (r TimeRange) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *TimeRange) UnmarshalText(data []byte) error {
+	var err error
+	*r, err = ParseTimeRange(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = TimeRange{}
+var _ encoding.TextUnmarshaler = &TimeRange{}
+
+func (r *TimeRange) ScanNull() error {
+	return fmt.Errorf("cannot scan NULL into timerange")
+}
+
+func (r *TimeRange) ScanBounds() (lowerTarget, upperTarget any) {
+	*r = TimeRange{}
+	return &r.Start, &r.End
+}
+
+func (r *TimeRange) SetBoundTypes(lower, upper pgtype.BoundType) error {
+	if lower == pgtype.Empty {
+		return fmt.Errorf("cannot scan empty range into TimeRange")
+	}
+	// Time has a precision of one second, so an exclusive start or an
+	// inclusive end moves to the next second.
+	if lower == pgtype.Exclusive {
+		r.Start = r.Start.Add(time.Second)
+	}
+	if upper == pgtype.Inclusive {
+		r.End = r.End.Add(time.Second)
+	}
+	if r.End.Hour == 24 {
+		r.End = Time{}
+	}
+	return nil
+}
+
+func (r TimeRange) IsNull() bool {
+	return false
+}
+
+func (r TimeRange) BoundTypes() (lower, upper pgtype.BoundType) {
+	return pgtype.Inclusive, pgtype.Exclusive
+}
+
+func (r TimeRange) Bounds() (lower, upper any) {
+	if r.CrossesMidnight() {
+		err := fmt.Errorf("cannot encode TimeRange %s crossing midnight", r)
+		return timeBoundError{err}, timeBoundError{err}
+	}
+
+	end := r.End
+	if end.IsZero() {
+		end = Time{Hour: 24}
+	}
+	return r.Start, end
+}
+
+var _ pgtype.RangeScanner = &TimeRange{}
+var _ pgtype.RangeValuer = TimeRange{}
+
+// timeBoundError is a range bound failing to encode with err, since Bounds
+// cannot return an error itself.
+type timeBoundError struct {
+	err error
+}
+
+func (b timeBoundError) TimeValue() (pgtype.Time, error) {
+	return pgtype.Time{}, b.err
+}
+
+var _ pgtype.TimeValuer = timeBoundError{}
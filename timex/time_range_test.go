@@ -0,0 +1,178 @@
+package timex
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeRangeDuration(t *testing.T) {
+	tests := []struct {
+		r        TimeRange
+		want     time.Duration
+		crossing bool
+	}{
+		{TimeRange{Time{Hour: 12}, Time{Hour: 14, Minute: 30}}, 150 * time.Minute, false},
+		{TimeRange{Time{Hour: 22}, Time{Hour: 2}}, 4 * time.Hour, true},
+		{TimeRange{Time{Hour: 22}, Time{}}, 2 * time.Hour, false},
+		{TimeRange{Time{}, Time{}}, Day, false},
+		{TimeRange{Time{Hour: 6}, Time{Hour: 6}}, Day, true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.r.Duration(), tt.r.String())
+		assert.Equal(t, tt.crossing, tt.r.CrossesMidnight(), tt.r.String())
+	}
+}
+
+func TestTimeRangeContains(t *testing.T) {
+	lunch := TimeRange{Time{Hour: 12}, Time{Hour: 14, Minute: 30}}
+	night := TimeRange{Time{Hour: 22}, Time{Hour: 2}}
+	allDay := TimeRange{Time{Hour: 6}, Time{Hour: 6}}
+
+	tests := []struct {
+		r    TimeRange
+		time Time
+		want bool
+	}{
+		{lunch, Time{Hour: 12}, true},
+		{lunch, Time{Hour: 14, Minute: 29, Second: 59}, true},
+		{lunch, Time{Hour: 14, Minute: 30}, false},
+		{lunch, Time{Hour: 11, Minute: 59}, false},
+		{night, Time{Hour: 23}, true},
+		{night, Time{}, true},
+		{night, Time{Hour: 1, Minute: 59}, true},
+		{night, Time{Hour: 2}, false},
+		{night, Time{Hour: 12}, false},
+		{allDay, Time{Hour: 5, Minute: 59}, true},
+		{allDay, Time{Hour: 6}, true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.r.Contains(tt.time), "%s contains %s", tt.r, tt.time)
+	}
+}
+
+func TestTimeRangeOverlaps(t *testing.T) {
+	tests := []struct {
+		r1, r2 TimeRange
+		want   bool
+	}{
+		{TimeRange{Time{Hour: 12}, Time{Hour: 14}}, TimeRange{Time{Hour: 13}, Time{Hour: 15}}, true},
+		{TimeRange{Time{Hour: 12}, Time{Hour: 14}}, TimeRange{Time{Hour: 14}, Time{Hour: 15}}, false},
+		{TimeRange{Time{Hour: 22}, Time{Hour: 2}}, TimeRange{Time{Hour: 1}, Time{Hour: 3}}, true},
+		{TimeRange{Time{Hour: 1}, Time{Hour: 3}}, TimeRange{Time{Hour: 22}, Time{Hour: 2}}, true},
+		{TimeRange{Time{Hour: 22}, Time{Hour: 2}}, TimeRange{Time{Hour: 2}, Time{Hour: 22}}, false},
+		{TimeRange{Time{Hour: 22}, Time{Hour: 2}}, TimeRange{Time{Hour: 23}, Time{Hour: 1}}, true},
+		{TimeRange{Time{Hour: 6}, Time{Hour: 6}}, TimeRange{Time{Hour: 3}, Time{Hour: 4}}, true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.r1.Overlaps(tt.r2), "%s overlaps %s", tt.r1, tt.r2)
+	}
+}
+
+func TestTimeRangeSlots(t *testing.T) {
+	lunch := TimeRange{Time{Hour: 12}, Time{Hour: 13}}
+	assert.Equal(t, []Time{
+		{Hour: 12},
+		{Hour: 12, Minute: 15},
+		{Hour: 12, Minute: 30},
+		{Hour: 12, Minute: 45},
+	}, slices.Collect(lunch.Slots(15*time.Minute)))
+
+	night := TimeRange{Time{Hour: 23}, Time{Hour: 1}}
+	assert.Equal(t, []Time{{Hour: 23}, {Hour: 0}}, slices.Collect(night.Slots(time.Hour)))
+
+	assert.Empty(t, slices.Collect(lunch.Slots(0)))
+}
+
+func TestParseTimeRange(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    TimeRange
+		wantErr bool
+	}{
+		{str: "12:00-14:30", want: TimeRange{Time{Hour: 12}, Time{Hour: 14, Minute: 30}}},
+		{str: "22:00-02:00", want: TimeRange{Time{Hour: 22}, Time{Hour: 2}}},
+		{str: "12:00:30-14:30", want: TimeRange{Time{Hour: 12, Second: 30}, Time{Hour: 14, Minute: 30}}},
+		{str: "12:00", wantErr: true},
+		{str: "12:00-", wantErr: true},
+		{str: "noon-14:30", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeRange(tt.str)
+		if tt.wantErr {
+			assert.Error(t, err, tt.str)
+			continue
+		}
+		assert.NoError(t, err, tt.str)
+		assert.Equal(t, tt.want, got, tt.str)
+	}
+
+	assert.Equal(t, "12:00:30-14:30", TimeRange{Time{Hour: 12, Second: 30}, Time{Hour: 14, Minute: 30}}.String())
+}
+
+func TestTimeRangeMarshalJSON(t *testing.T) {
+	r := TimeRange{Time{Hour: 22}, Time{Hour: 2}}
+
+	got, err := json.Marshal(r)
+	assert.NoError(t, err)
+	assert.Equal(t, `"22:00-02:00"`, string(got))
+
+	var decoded TimeRange
+	assert.NoError(t, json.Unmarshal(got, &decoded))
+	assert.Equal(t, r, decoded)
+}
+
+func TestTimeRangeRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+	timeType, _ := m.TypeForOID(pgtype.TimeOID)
+	const timeRangeOID = 100000
+	m.RegisterType(&pgtype.Type{Name: "timerange", OID: timeRangeOID, Codec: &pgtype.RangeCodec{ElementType: timeType}})
+
+	tests := []TimeRange{
+		{Time{Hour: 12}, Time{Hour: 14, Minute: 30}},
+		{Time{Hour: 22}, Time{}},
+		{Time{}, Time{}},
+		{Time{Hour: 12, Second: 1}, Time{Hour: 12, Second: 2}},
+	}
+
+	for _, original := range tests {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			buf, err := m.Encode(timeRangeOID, format, original, nil)
+			assert.NoError(t, err)
+
+			var decoded TimeRange
+			err = m.Scan(timeRangeOID, format, buf, &decoded)
+			assert.NoError(t, err)
+			assert.Equal(t, original, decoded)
+		}
+	}
+
+	buf, err := m.Encode(timeRangeOID, pgtype.TextFormatCode, TimeRange{Time{Hour: 22}, Time{}}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "[22:00:00.000000,24:00:00.000000)", string(buf))
+
+	var decoded TimeRange
+	assert.NoError(t, m.Scan(timeRangeOID, pgtype.TextFormatCode, []byte("(12:00:00,14:00:00]"), &decoded))
+	assert.Equal(t, TimeRange{Time{Hour: 12, Second: 1}, Time{Hour: 14, Second: 1}}, decoded)
+	assert.NoError(t, m.Scan(timeRangeOID, pgtype.TextFormatCode, []byte("[12:00:00,)"), &decoded))
+	assert.Equal(t, TimeRange{Time{Hour: 12}, Time{}}, decoded)
+	assert.Error(t, m.Scan(timeRangeOID, pgtype.TextFormatCode, []byte("empty"), &decoded))
+
+	for _, r := range []TimeRange{
+		{Time{Hour: 22}, Time{Hour: 2}},
+		{Time{Hour: 8}, Time{Hour: 8}},
+	} {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			_, err := m.Encode(timeRangeOID, format, r, nil)
+			assert.Error(t, err, r.String())
+		}
+	}
+}
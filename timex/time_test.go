@@ -158,3 +158,49 @@ func TestMicrosecondsRoundTrip(t *testing.T) {
 		assert.Equal(t, tt, reconstructed)
 	}
 }
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    Time
+		wantErr bool
+	}{
+		{str: "13:26", want: Time{Hour: 13, Minute: 26}},
+		{str: "13:26:33", want: Time{Hour: 13, Minute: 26, Second: 33}},
+		{str: "13:26:33.5", want: Time{Hour: 13, Minute: 26, Second: 33}},
+		{str: "13", wantErr: true},
+		{str: "25:00", wantErr: true},
+		{str: "13:26:33Z", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTime(tt.str)
+		if tt.wantErr {
+			assert.Error(t, err, tt.str)
+			continue
+		}
+		assert.NoError(t, err, tt.str)
+		assert.Equal(t, tt.want, got, tt.str)
+	}
+}
+
+func TestTimeAdd(t *testing.T) {
+	tests := []struct {
+		time Time
+		d    time.Duration
+		want Time
+	}{
+		{Time{12, 0, 0}, 90 * time.Minute, Time{13, 30, 0}},
+		{Time{23, 0, 0}, 3 * time.Hour, Time{2, 0, 0}},
+		{Time{1, 0, 0}, -2 * time.Hour, Time{23, 0, 0}},
+		{Time{12, 0, 0}, 3 * Day, Time{12, 0, 0}},
+		{Time{12, 0, 0}, 1500 * time.Millisecond, Time{12, 0, 1}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.time.Add(tt.d))
+	}
+
+	assert.Equal(t, 150*time.Minute, Time{14, 30, 0}.Sub(Time{12, 0, 0}))
+	assert.Equal(t, -20*time.Hour, Time{2, 0, 0}.Sub(Time{22, 0, 0}))
+}
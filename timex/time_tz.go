@@ -0,0 +1,144 @@
+package timex
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeTZ is a time of day with a fixed UTC offset, the equivalent of a
+// Postgres timetz.
+type TimeTZ struct {
+	Time PreciseTime
+	// Offset is the offset in seconds east of UTC.
+	Offset int
+}
+
+// NewTimeTZ returns the wall clock time of t together with its offset.
+func NewTimeTZ(t time.Time) TimeTZ {
+	_, offset := t.Zone()
+	return TimeTZ{
+		Time:   NewPreciseTime(t),
+		Offset: offset,
+	}
+}
+
+// ParseTimeTZ parses a time of day as accepted by ParsePreciseTime followed by an
+// offset in the form +hh, +hh:mm or +hh:mm:ss, e.g. 15:04:05.123+02 or
+// 09:30-05:30. Z is accepted for UTC.
+func ParseTimeTZ(s string) (TimeTZ, error) {
+	if strings.HasSuffix(s, "Z") {
+		t, err := ParsePreciseTime(strings.TrimSuffix(s, "Z"))
+		return TimeTZ{Time: t}, err
+	}
+
+	i := strings.LastIndexAny(s, "+-")
+	if i < 0 {
+		return TimeTZ{}, fmt.Errorf("missing offset in %q", s)
+	}
+	t, err := ParsePreciseTime(s[:i])
+	if err != nil {
+		return TimeTZ{}, err
+	}
+	offset, err := parseOffset(s[i:])
+	if err != nil {
+		return TimeTZ{}, err
+	}
+	return TimeTZ{Time: t, Offset: offset}, nil
+}
+
+func parseOffset(s string) (int, error) {
+	sign := 1
+	if s[0] == '-' {
+		sign = -1
+	}
+
+	parts := strings.Split(s[1:], ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid offset %q", s)
+	}
+
+	var offset int
+	for i, part := range parts {
+		if len(part) != 2 || !isDigits(part) {
+			return 0, fmt.Errorf("invalid offset %q", s)
+		}
+		n, _ := strconv.Atoi(part)
+		if i > 0 && n > 59 {
+			return 0, fmt.Errorf("invalid offset %q", s)
+		}
+		offset = offset*60 + n
+	}
+	for range 3 - len(parts) {
+		offset *= 60
+	}
+	return sign * offset, nil
+}
+
+// Location returns a fixed zone with t's offset.
+func (t TimeTZ) Location() *time.Location {
+	return time.FixedZone("", t.Offset)
+}
+
+// On returns the instant at which t occurs on d.
+func (t TimeTZ) On(d Date) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, t.Time.Hour, t.Time.Minute, t.Time.Second, t.Time.Nanosecond, t.Location())
+}
+
+// String returns the time as formatted by PreciseTime.String followed by the offset,
+// e.g. 15:04:05+02 or 09:30:00-05:30.
+func (t TimeTZ) String() string {
+	sign := byte('+')
+	offset := t.Offset
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+
+	s := fmt.Sprintf("%s%c%02d", t.Time, sign, offset/3600)
+	if offset%3600 != 0 {
+		s += fmt.Sprintf(":%02d", offset%3600/60)
+	}
+	if offset%60 != 0 {
+		s += fmt.Sprintf(":%02d", offset%60)
+	}
+	return s
+}
+
+func (t TimeTZ) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *TimeTZ) UnmarshalText(data []byte) error {
+	var err error
+	*t, err = ParseTimeTZ(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = TimeTZ{}
+var _ encoding.TextUnmarshaler = &TimeTZ{}
+
+// Scan implements sql.Scanner. pgx has no codec for timetz, so values are
+// exchanged in the Postgres text format.
+func (t *TimeTZ) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*t = TimeTZ{}
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(src))
+	case []byte:
+		return t.UnmarshalText(src)
+	}
+	return fmt.Errorf("cannot scan %T into TimeTZ", src)
+}
+
+func (t TimeTZ) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+var _ sql.Scanner = &TimeTZ{}
+var _ driver.Valuer = TimeTZ{}
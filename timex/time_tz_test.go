@@ -0,0 +1,64 @@
+package timex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeTZ(t *testing.T) {
+	tests := []struct {
+		str     string
+		want    TimeTZ
+		wantStr string
+		wantErr bool
+	}{
+		{str: "15:04:05+02", want: TimeTZ{PreciseTime{Time{15, 4, 5}, 0}, 7200}, wantStr: "15:04:05+02"},
+		{str: "15:04:05.25+02", want: TimeTZ{PreciseTime{Time{15, 4, 5}, 250_000_000}, 7200}, wantStr: "15:04:05.25+02"},
+		{str: "09:30-05:30", want: TimeTZ{PreciseTime{Time{9, 30, 0}, 0}, -19800}, wantStr: "09:30:00-05:30"},
+		{str: "09:30:00+00:09:21", want: TimeTZ{PreciseTime{Time{9, 30, 0}, 0}, 561}, wantStr: "09:30:00+00:09:21"},
+		{str: "09:30:00Z", want: TimeTZ{PreciseTime{Time{9, 30, 0}, 0}, 0}, wantStr: "09:30:00+00"},
+		{str: "09:30:00", wantErr: true},
+		{str: "09:30:00+2", wantErr: true},
+		{str: "09:30:00+02:60", wantErr: true},
+		{str: "09:30:00+02:00:00:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeTZ(tt.str)
+		if tt.wantErr {
+			assert.Error(t, err, tt.str)
+			continue
+		}
+		assert.NoError(t, err, tt.str)
+		assert.Equal(t, tt.want, got, tt.str)
+		assert.Equal(t, tt.wantStr, got.String(), tt.str)
+	}
+}
+
+func TestTimeTZOn(t *testing.T) {
+	tz := TimeTZ{PreciseTime{Time{15, 0, 0}, 0}, 7200}
+	assert.True(t, tz.On(Date{2025, 7, 1}).Equal(time.Date(2025, 7, 1, 13, 0, 0, 0, time.UTC)))
+
+	assert.Equal(t, tz, NewTimeTZ(tz.On(Date{2025, 7, 1})))
+}
+
+func TestTimeTZRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+	original := TimeTZ{PreciseTime{Time{15, 4, 5}, 123_456_000}, -19800}
+
+	buf, err := m.Encode(pgtype.TimetzOID, pgtype.TextFormatCode, original, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "15:04:05.123456-05:30", string(buf))
+
+	var decoded TimeTZ
+	err = m.Scan(pgtype.TimetzOID, pgtype.TextFormatCode, buf, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, original, decoded)
+
+	assert.NoError(t, decoded.Scan(nil))
+	assert.Equal(t, TimeTZ{}, decoded)
+	assert.Error(t, decoded.Scan(42))
+}
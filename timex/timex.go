@@ -1,6 +1,7 @@
 package timex
 
 import (
+	"iter"
 	"time"
 )
 
@@ -32,3 +33,33 @@ func MakeDateSeries(start, stop Date, intervalDays int) []Date {
 	}
 	return series
 }
+
+// TimeSeries is the lazy counterpart of MakeTimeSeries. It yields the same
+// values without allocating the whole series up front.
+func TimeSeries(start, stop time.Time, interval time.Duration) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if interval <= 0 {
+			return
+		}
+		for t := start; !t.After(stop); t = t.Add(interval) {
+			if !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// DateSeries is the lazy counterpart of MakeDateSeries. It yields the same
+// values without allocating the whole series up front.
+func DateSeries(start, stop Date, intervalDays int) iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		if intervalDays <= 0 {
+			return
+		}
+		for d := start; !d.After(stop); d = d.AddDays(intervalDays) {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
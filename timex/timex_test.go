@@ -47,3 +47,47 @@ func TestMakeDateSeries(t *testing.T) {
 		}))
 	})
 }
+
+func TestTimeSeries(t *testing.T) {
+	start := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	stop := start.Add(time.Hour)
+
+	t.Run("should yield nothing", func(t *testing.T) {
+		assert.Empty(t, slices.Collect(TimeSeries(start, stop, 0)))
+		assert.Empty(t, slices.Collect(TimeSeries(stop, start, time.Minute)))
+	})
+
+	t.Run("should match MakeTimeSeries", func(t *testing.T) {
+		actual := slices.Collect(TimeSeries(start, stop, 15*time.Minute))
+		assert.Equal(t, MakeTimeSeries(start, stop, 15*time.Minute), actual)
+		assert.Len(t, actual, 5)
+	})
+
+	t.Run("should stop early", func(t *testing.T) {
+		var n int
+		for range TimeSeries(start, stop, time.Minute) {
+			n++
+			if n == 3 {
+				break
+			}
+		}
+		assert.Equal(t, 3, n)
+	})
+}
+
+func TestDateSeries(t *testing.T) {
+	start := Date{2025, 7, 1}
+	stop := start.AddDays(9)
+
+	t.Run("should yield nothing", func(t *testing.T) {
+		assert.Empty(t, slices.Collect(DateSeries(start, stop, 0)))
+		assert.Empty(t, slices.Collect(DateSeries(stop, start, 1)))
+	})
+
+	t.Run("should match MakeDateSeries", func(t *testing.T) {
+		for _, interval := range []int{1, 2, 3, 10} {
+			actual := slices.Collect(DateSeries(start, stop, interval))
+			assert.Equal(t, MakeDateSeries(start, stop, interval), actual)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package timex
+
+import (
+	"encoding"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// YearMonth is a calendar month of a specific year, e.g. 2025-07.
+type YearMonth struct {
+	Year  int
+	Month time.Month
+}
+
+func ParseYearMonth(s string) (YearMonth, error) {
+	t, err := time.Parse("2006-01", s)
+	if err != nil {
+		return YearMonth{}, err
+	}
+	return YearMonth{Year: t.Year(), Month: t.Month()}, nil
+}
+
+func (d Date) YearMonth() YearMonth {
+	return YearMonth{Year: d.Year, Month: d.Month}
+}
+
+func (m YearMonth) FirstDay() Date {
+	return Date{m.Year, m.Month, 1}
+}
+
+func (m YearMonth) LastDay() Date {
+	return Date{m.Year, m.Month, daysIn(m.Month, m.Year)}
+}
+
+func (m YearMonth) Contains(d Date) bool {
+	return d.Year == m.Year && d.Month == m.Month
+}
+
+func (m YearMonth) DateRange() DateRange {
+	return DateRange{Start: m.FirstDay(), End: m.LastDay()}
+}
+
+func (m YearMonth) AddMonths(n int) YearMonth {
+	t := time.Date(m.Year, m.Month+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	return YearMonth{Year: t.Year(), Month: t.Month()}
+}
+
+func (m YearMonth) Before(m2 YearMonth) bool {
+	if m.Year != m2.Year {
+		return m.Year < m2.Year
+	}
+	return m.Month < m2.Month
+}
+
+func (m YearMonth) After(m2 YearMonth) bool {
+	return m2.Before(m)
+}
+
+func (m YearMonth) Compare(m2 YearMonth) int {
+	if m.Before(m2) {
+		return -1
+	}
+	if m.After(m2) {
+		return +1
+	}
+	return 0
+}
+
+func (m YearMonth) IsZero() bool {
+	return m == YearMonth{}
+}
+
+func (m YearMonth) String() string {
+	if m.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d", m.Year, m.Month)
+}
+
+func (m YearMonth) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *YearMonth) UnmarshalText(data []byte) error {
+	var err error
+	*m, err = ParseYearMonth(string(data))
+	return err
+}
+
+var _ encoding.TextMarshaler = YearMonth{}
+var _ encoding.TextUnmarshaler = &YearMonth{}
+
+// Months returns an iterator over the parts of d falling into each calendar
+// month, in ascending order. It yields nothing if d has an open start and
+// never stops if d has an open end.
+func (d DateRange) Months() iter.Seq2[YearMonth, DateRange] {
+	return func(yield func(YearMonth, DateRange) bool) {
+		if !d.hasStart() || d.IsEmpty() {
+			return
+		}
+		for m := d.Start.YearMonth(); d.Contains(m.FirstDay()) || m.Contains(d.Start); m = m.AddMonths(1) {
+			part, _ := d.Intersect(m.DateRange())
+			if !yield(m, part) {
+				return
+			}
+		}
+	}
+}
+
+func daysIn(m time.Month, year int) int {
+	return time.Date(year, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
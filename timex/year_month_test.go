@@ -0,0 +1,125 @@
+package timex
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseYearMonth(t *testing.T) {
+	tests := []struct {
+		str  string
+		want YearMonth // if zero, expect an error
+	}{
+		{"2025-07", YearMonth{2025, time.July}},
+		{"0003-12", YearMonth{3, time.December}},
+		{"2025-13", YearMonth{}},
+		{"2025-7", YearMonth{}},
+		{"2025-07-01", YearMonth{}},
+		{"", YearMonth{}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseYearMonth(tt.str)
+		assert.Equal(t, tt.want, got, tt.str)
+		if got.IsZero() {
+			assert.Error(t, err, tt.str)
+		}
+	}
+}
+
+func TestYearMonthDays(t *testing.T) {
+	tests := []struct {
+		m                 YearMonth
+		firstDay, lastDay Date
+	}{
+		{YearMonth{2025, time.January}, Date{2025, 1, 1}, Date{2025, 1, 31}},
+		{YearMonth{2024, time.February}, Date{2024, 2, 1}, Date{2024, 2, 29}},
+		{YearMonth{2025, time.February}, Date{2025, 2, 1}, Date{2025, 2, 28}},
+		{YearMonth{1900, time.February}, Date{1900, 2, 1}, Date{1900, 2, 28}},
+		{YearMonth{2025, time.April}, Date{2025, 4, 1}, Date{2025, 4, 30}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.firstDay, tt.m.FirstDay(), tt.m.String())
+		assert.Equal(t, tt.lastDay, tt.m.LastDay(), tt.m.String())
+		assert.Equal(t, DateRange{Start: tt.firstDay, End: tt.lastDay}, tt.m.DateRange(), tt.m.String())
+		assert.True(t, tt.m.Contains(tt.firstDay))
+		assert.True(t, tt.m.Contains(tt.lastDay))
+		assert.False(t, tt.m.Contains(tt.lastDay.AddDays(1)))
+		assert.False(t, tt.m.Contains(tt.firstDay.AddDays(-1)))
+		assert.Equal(t, tt.m, tt.lastDay.YearMonth())
+	}
+}
+
+func TestYearMonthAddMonths(t *testing.T) {
+	m := YearMonth{2025, time.November}
+
+	assert.Equal(t, YearMonth{2025, time.December}, m.AddMonths(1))
+	assert.Equal(t, YearMonth{2026, time.January}, m.AddMonths(2))
+	assert.Equal(t, YearMonth{2024, time.November}, m.AddMonths(-12))
+	assert.Equal(t, YearMonth{2024, time.December}, m.AddMonths(-11))
+	assert.Equal(t, m, m.AddMonths(0))
+}
+
+func TestYearMonthCompare(t *testing.T) {
+	tests := []struct {
+		m1, m2 YearMonth
+		want   int
+	}{
+		{YearMonth{2024, time.December}, YearMonth{2025, time.January}, -1},
+		{YearMonth{2025, time.January}, YearMonth{2025, time.February}, -1},
+		{YearMonth{2025, time.January}, YearMonth{2025, time.January}, 0},
+		{YearMonth{2025, time.March}, YearMonth{2025, time.February}, +1},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.m1.Compare(tt.m2))
+	}
+}
+
+func TestYearMonthMarshalJSON(t *testing.T) {
+	got, err := json.Marshal(YearMonth{2025, time.July})
+	assert.NoError(t, err)
+	assert.Equal(t, `"2025-07"`, string(got))
+
+	var m YearMonth
+	assert.NoError(t, json.Unmarshal(got, &m))
+	assert.Equal(t, YearMonth{2025, time.July}, m)
+}
+
+func TestDateRangeMonths(t *testing.T) {
+	r := DateRange{Start: Date{2024, 12, 15}, End: Date{2025, 2, 10}}
+
+	var months []YearMonth
+	var parts []DateRange
+	for m, part := range r.Months() {
+		months = append(months, m)
+		parts = append(parts, part)
+	}
+
+	assert.Equal(t, []YearMonth{{2024, time.December}, {2025, time.January}, {2025, time.February}}, months)
+	assert.Equal(t, []DateRange{
+		{Start: Date{2024, 12, 15}, End: Date{2024, 12, 31}},
+		{Start: Date{2025, 1, 1}, End: Date{2025, 1, 31}},
+		{Start: Date{2025, 2, 1}, End: Date{2025, 2, 10}},
+	}, parts)
+
+	single := DateRange{Start: Date{2025, 7, 1}, End: Date{2025, 7, 1}}
+	for m, part := range single.Months() {
+		assert.Equal(t, YearMonth{2025, time.July}, m)
+		assert.Equal(t, single, part)
+	}
+
+	from := DateRange{Start: Date{2025, 7, 20}, EndBound: Unbounded}
+	months = nil
+	for m := range from.Months() {
+		months = append(months, m)
+		if len(months) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []YearMonth{{2025, time.July}, {2025, time.August}}, months)
+}